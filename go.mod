@@ -3,10 +3,12 @@ module github.com/txsvc/slack
 go 1.14
 
 require (
+	cloud.google.com/go/datastore v1.3.0
 	github.com/gin-gonic/gin v1.6.3
 	github.com/txsvc/commons v1.1.0
 	github.com/txsvc/platform v1.0.0
 	github.com/txsvc/service v1.0.0
 	golang.org/x/net v0.0.0-20201224014010-6772e930b67b
 	google.golang.org/appengine v1.6.7
+	gopkg.in/yaml.v2 v2.4.0
 )
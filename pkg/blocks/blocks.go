@@ -0,0 +1,493 @@
+package blocks
+
+import (
+	"encoding/json"
+
+	"github.com/txsvc/slack/pkg/slack"
+)
+
+// see https://api.slack.com/reference/block-kit/blocks
+
+type (
+	// BlockBuilder is implemented by all block builders. Builders can be used wherever
+	// a slack.Block is expected, Blocks and Message unwrap them into the underlying types.
+	// The block being built is not exposed, use Block to get it.
+	BlockBuilder interface {
+		slack.Block
+		Block() slack.Block
+	}
+
+	// ElementBuilder is implemented by all element builders. Builders can be used wherever
+	// a slack.BlockElement is expected.
+	ElementBuilder interface {
+		slack.BlockElement
+		Element() slack.BlockElement
+	}
+
+	// ViewBuilder builds a modal or Home tab
+	ViewBuilder struct {
+		view *slack.ViewElement
+	}
+
+	// SectionBuilder builds a section block
+	SectionBuilder struct {
+		block *slack.SectionBlock
+	}
+
+	// DividerBuilder builds a divider block
+	DividerBuilder struct {
+		block *slack.DividerBlock
+	}
+
+	// HeaderBuilder builds a header block
+	HeaderBuilder struct {
+		block *slack.HeaderBlock
+	}
+
+	// ContextBuilder builds a context block
+	ContextBuilder struct {
+		block *slack.ContextBlock
+	}
+
+	// ImageBuilder builds an image block
+	ImageBuilder struct {
+		block *slack.ImageBlock
+	}
+
+	// ActionsBuilder builds an actions block
+	ActionsBuilder struct {
+		block *slack.ActionsBlock
+	}
+
+	// FileBuilder builds a remote file block
+	FileBuilder struct {
+		block *slack.FileBlock
+	}
+
+	// VideoBuilder builds a video block
+	VideoBuilder struct {
+		block *slack.VideoBlock
+	}
+
+	// InputBuilder builds an input block
+	InputBuilder struct {
+		block *slack.InputBlock
+	}
+
+	// RichTextBuilder builds a rich_text block
+	RichTextBuilder struct {
+		block *slack.RichTextBlock
+	}
+)
+
+// Blocks unwraps builders into a list of blocks
+func Blocks(blocks ...slack.Block) []slack.Block {
+	result := make([]slack.Block, len(blocks))
+	for i := range blocks {
+		result[i] = unwrapBlock(blocks[i])
+	}
+	return result
+}
+
+// Message creates a message from blocks
+func Message(blocks ...slack.Block) *slack.SectionBlocks {
+	return &slack.SectionBlocks{Blocks: Blocks(blocks...)}
+}
+
+// Modal starts a modal view
+func Modal(title string) *ViewBuilder {
+	return &ViewBuilder{&slack.ViewElement{
+		Type:  "modal",
+		Title: slack.DefaultViewElement{Type: PlainTextType, Text: title},
+	}}
+}
+
+// Home starts a Home tab view
+func Home() *ViewBuilder {
+	return &ViewBuilder{&slack.ViewElement{Type: "home"}}
+}
+
+// Submit sets the label of the submit button
+func (v *ViewBuilder) Submit(text string) *ViewBuilder {
+	v.view.Submit = &slack.DefaultViewElement{Type: PlainTextType, Text: text}
+	return v
+}
+
+// Close sets the label of the close button
+func (v *ViewBuilder) Close(text string) *ViewBuilder {
+	v.view.Close = &slack.DefaultViewElement{Type: PlainTextType, Text: text}
+	return v
+}
+
+// CallbackID sets the callback ID
+func (v *ViewBuilder) CallbackID(id string) *ViewBuilder {
+	v.view.CallbackID = id
+	return v
+}
+
+// PrivateMetadata sets the private metadata
+func (v *ViewBuilder) PrivateMetadata(metadata string) *ViewBuilder {
+	v.view.PrivateMetadata = metadata
+	return v
+}
+
+// ExternalID sets the external ID
+func (v *ViewBuilder) ExternalID(id string) *ViewBuilder {
+	v.view.ExternalID = id
+	return v
+}
+
+// Blocks appends blocks to the view
+func (v *ViewBuilder) Blocks(blocks ...slack.Block) *ViewBuilder {
+	v.view.Blocks = append(v.view.Blocks, Blocks(blocks...)...)
+	return v
+}
+
+// View returns the view
+func (v *ViewBuilder) View() *slack.ViewElement {
+	return v.view
+}
+
+// Section starts a section block
+func Section() *SectionBuilder {
+	return &SectionBuilder{&slack.SectionBlock{}}
+}
+
+// Markdown sets the text of the section as mrkdwn
+func (b *SectionBuilder) Markdown(text string) *SectionBuilder {
+	b.block.Text = Markdown(text)
+	return b
+}
+
+// PlainText sets the text of the section as plain_text
+func (b *SectionBuilder) PlainText(text string) *SectionBuilder {
+	b.block.Text = PlainText(text)
+	return b
+}
+
+// Fields appends fields to the section
+func (b *SectionBuilder) Fields(fields ...slack.TextObject) *SectionBuilder {
+	b.block.Fields = append(b.block.Fields, fields...)
+	return b
+}
+
+// MarkdownFields appends mrkdwn fields to the section
+func (b *SectionBuilder) MarkdownFields(texts ...string) *SectionBuilder {
+	for _, t := range texts {
+		b.block.Fields = append(b.block.Fields, Markdown(t))
+	}
+	return b
+}
+
+// Accessory sets the accessory of the section
+func (b *SectionBuilder) Accessory(e slack.BlockElement) *SectionBuilder {
+	b.block.Accessory = unwrapElement(e)
+	return b
+}
+
+// ID sets the block ID
+func (b *SectionBuilder) ID(id string) *SectionBuilder {
+	b.block.BlockID = id
+	return b
+}
+
+// Block returns the section block
+func (b *SectionBuilder) Block() slack.Block {
+	return b.block
+}
+
+// Divider creates a divider block
+func Divider() *DividerBuilder {
+	return &DividerBuilder{&slack.DividerBlock{}}
+}
+
+// ID sets the block ID
+func (b *DividerBuilder) ID(id string) *DividerBuilder {
+	b.block.BlockID = id
+	return b
+}
+
+// Block returns the divider block
+func (b *DividerBuilder) Block() slack.Block {
+	return b.block
+}
+
+// Header creates a header block
+func Header(text string) *HeaderBuilder {
+	return &HeaderBuilder{&slack.HeaderBlock{Text: PlainText(text)}}
+}
+
+// ID sets the block ID
+func (b *HeaderBuilder) ID(id string) *HeaderBuilder {
+	b.block.BlockID = id
+	return b
+}
+
+// Block returns the header block
+func (b *HeaderBuilder) Block() slack.Block {
+	return b.block
+}
+
+// Context creates a context block from text objects and image elements
+func Context(elements ...slack.ContextElement) *ContextBuilder {
+	result := make([]slack.ContextElement, len(elements))
+	for i := range elements {
+		result[i] = unwrapContextElement(elements[i])
+	}
+	return &ContextBuilder{&slack.ContextBlock{Elements: result}}
+}
+
+// ID sets the block ID
+func (b *ContextBuilder) ID(id string) *ContextBuilder {
+	b.block.BlockID = id
+	return b
+}
+
+// Block returns the context block
+func (b *ContextBuilder) Block() slack.Block {
+	return b.block
+}
+
+// Image creates an image block
+func Image(imageURL, altText string) *ImageBuilder {
+	return &ImageBuilder{&slack.ImageBlock{ImageURL: imageURL, AltText: altText}}
+}
+
+// Title sets the title of the image
+func (b *ImageBuilder) Title(text string) *ImageBuilder {
+	b.block.Title = textPtr(PlainText(text))
+	return b
+}
+
+// ID sets the block ID
+func (b *ImageBuilder) ID(id string) *ImageBuilder {
+	b.block.BlockID = id
+	return b
+}
+
+// Block returns the image block
+func (b *ImageBuilder) Block() slack.Block {
+	return b.block
+}
+
+// Actions creates an actions block from interactive elements
+func Actions(elements ...slack.BlockElement) *ActionsBuilder {
+	return &ActionsBuilder{&slack.ActionsBlock{Elements: unwrapElements(elements)}}
+}
+
+// ID sets the block ID
+func (b *ActionsBuilder) ID(id string) *ActionsBuilder {
+	b.block.BlockID = id
+	return b
+}
+
+// Block returns the actions block
+func (b *ActionsBuilder) Block() slack.Block {
+	return b.block
+}
+
+// File creates a remote file block
+func File(externalID string) *FileBuilder {
+	return &FileBuilder{&slack.FileBlock{ExternalID: externalID, Source: "remote"}}
+}
+
+// ID sets the block ID
+func (b *FileBuilder) ID(id string) *FileBuilder {
+	b.block.BlockID = id
+	return b
+}
+
+// Block returns the file block
+func (b *FileBuilder) Block() slack.Block {
+	return b.block
+}
+
+// Video creates a video block
+func Video(title, videoURL, thumbnailURL, altText string) *VideoBuilder {
+	return &VideoBuilder{&slack.VideoBlock{
+		Title:        PlainText(title),
+		VideoURL:     videoURL,
+		ThumbnailURL: thumbnailURL,
+		AltText:      altText,
+	}}
+}
+
+// Description sets the description of the video
+func (b *VideoBuilder) Description(text string) *VideoBuilder {
+	b.block.Description = textPtr(PlainText(text))
+	return b
+}
+
+// TitleURL sets the link of the title
+func (b *VideoBuilder) TitleURL(url string) *VideoBuilder {
+	b.block.TitleURL = url
+	return b
+}
+
+// Author sets the author of the video
+func (b *VideoBuilder) Author(name string) *VideoBuilder {
+	b.block.AuthorName = name
+	return b
+}
+
+// Provider sets the name and icon of the video provider
+func (b *VideoBuilder) Provider(name, iconURL string) *VideoBuilder {
+	b.block.ProviderName = name
+	b.block.ProviderIconURL = iconURL
+	return b
+}
+
+// ID sets the block ID
+func (b *VideoBuilder) ID(id string) *VideoBuilder {
+	b.block.BlockID = id
+	return b
+}
+
+// Block returns the video block
+func (b *VideoBuilder) Block() slack.Block {
+	return b.block
+}
+
+// Input creates an input block
+func Input(label string, element slack.BlockElement) *InputBuilder {
+	return &InputBuilder{&slack.InputBlock{Label: PlainText(label), Element: unwrapElement(element)}}
+}
+
+// Hint sets the hint shown below the input
+func (b *InputBuilder) Hint(text string) *InputBuilder {
+	b.block.Hint = textPtr(PlainText(text))
+	return b
+}
+
+// Optional marks the input as optional
+func (b *InputBuilder) Optional() *InputBuilder {
+	b.block.Optional = true
+	return b
+}
+
+// DispatchAction sends block_actions payloads when the input changes
+func (b *InputBuilder) DispatchAction() *InputBuilder {
+	b.block.DispatchAction = true
+	return b
+}
+
+// ID sets the block ID
+func (b *InputBuilder) ID(id string) *InputBuilder {
+	b.block.BlockID = id
+	return b
+}
+
+// Block returns the input block
+func (b *InputBuilder) Block() slack.Block {
+	return b.block
+}
+
+// RichText creates a rich_text block
+func RichText(elements ...slack.RichTextElement) *RichTextBuilder {
+	return &RichTextBuilder{&slack.RichTextBlock{Elements: elements}}
+}
+
+// ID sets the block ID
+func (b *RichTextBuilder) ID(id string) *RichTextBuilder {
+	b.block.BlockID = id
+	return b
+}
+
+// Block returns the rich_text block
+func (b *RichTextBuilder) Block() slack.Block {
+	return b.block
+}
+
+// MarshalJSON marshals the view
+func (v *ViewBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(v.view) }
+
+// BlockType implements slack.Block
+func (b *SectionBuilder) BlockType() string { return b.block.BlockType() }
+
+// BlockType implements slack.Block
+func (b *DividerBuilder) BlockType() string { return b.block.BlockType() }
+
+// BlockType implements slack.Block
+func (b *HeaderBuilder) BlockType() string { return b.block.BlockType() }
+
+// BlockType implements slack.Block
+func (b *ContextBuilder) BlockType() string { return b.block.BlockType() }
+
+// BlockType implements slack.Block
+func (b *ImageBuilder) BlockType() string { return b.block.BlockType() }
+
+// BlockType implements slack.Block
+func (b *ActionsBuilder) BlockType() string { return b.block.BlockType() }
+
+// BlockType implements slack.Block
+func (b *FileBuilder) BlockType() string { return b.block.BlockType() }
+
+// BlockType implements slack.Block
+func (b *VideoBuilder) BlockType() string { return b.block.BlockType() }
+
+// BlockType implements slack.Block
+func (b *InputBuilder) BlockType() string { return b.block.BlockType() }
+
+// BlockType implements slack.Block
+func (b *RichTextBuilder) BlockType() string { return b.block.BlockType() }
+
+// MarshalJSON marshals the block
+func (b *SectionBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.block) }
+
+// MarshalJSON marshals the block
+func (b *DividerBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.block) }
+
+// MarshalJSON marshals the block
+func (b *HeaderBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.block) }
+
+// MarshalJSON marshals the block
+func (b *ContextBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.block) }
+
+// MarshalJSON marshals the block
+func (b *ImageBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.block) }
+
+// MarshalJSON marshals the block
+func (b *ActionsBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.block) }
+
+// MarshalJSON marshals the block
+func (b *FileBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.block) }
+
+// MarshalJSON marshals the block
+func (b *VideoBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.block) }
+
+// MarshalJSON marshals the block
+func (b *InputBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.block) }
+
+// MarshalJSON marshals the block
+func (b *RichTextBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.block) }
+
+func unwrapBlock(b slack.Block) slack.Block {
+	if bb, ok := b.(BlockBuilder); ok {
+		return bb.Block()
+	}
+	return b
+}
+
+func unwrapElement(e slack.BlockElement) slack.BlockElement {
+	if eb, ok := e.(ElementBuilder); ok {
+		return eb.Element()
+	}
+	return e
+}
+
+func unwrapContextElement(e slack.ContextElement) slack.ContextElement {
+	if eb, ok := e.(ElementBuilder); ok {
+		if ce, ok := eb.Element().(slack.ContextElement); ok {
+			return ce
+		}
+	}
+	return e
+}
+
+func unwrapElements(elements []slack.BlockElement) []slack.BlockElement {
+	result := make([]slack.BlockElement, len(elements))
+	for i := range elements {
+		result[i] = unwrapElement(elements[i])
+	}
+	return result
+}
@@ -0,0 +1,104 @@
+package blocks
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/txsvc/slack/pkg/slack"
+)
+
+func TestBuilders(t *testing.T) {
+	tests := []struct {
+		name  string
+		block slack.Block
+		want  string
+	}{
+		{
+			"section",
+			Section().Markdown("*hi*").MarkdownFields("a", "b").Accessory(Button("Go", "go").Value("1").Primary()).ID("s1"),
+			`{"type":"section","block_id":"s1","text":{"type":"mrkdwn","text":"*hi*"},"fields":[{"type":"mrkdwn","text":"a"},{"type":"mrkdwn","text":"b"}],"accessory":{"type":"button","text":{"type":"plain_text","text":"Go"},"action_id":"go","value":"1","style":"primary"}}`,
+		},
+		{
+			"divider",
+			Divider(),
+			`{"type":"divider"}`,
+		},
+		{
+			"header",
+			Header("Title").ID("h"),
+			`{"type":"header","block_id":"h","text":{"type":"plain_text","text":"Title"}}`,
+		},
+		{
+			"context",
+			Context(Markdown("by"), ImageElement("https://example.com/a.png", "avatar")),
+			`{"type":"context","elements":[{"type":"mrkdwn","text":"by"},{"type":"image","image_url":"https://example.com/a.png","alt_text":"avatar"}]}`,
+		},
+		{
+			"actions",
+			Actions(Button("Delete", "delete").Danger().Confirm(Confirm("Sure?", "Really", "Yes", "No")), Overflow("more", Option("Edit", "edit"))),
+			`{"type":"actions","elements":[{"type":"button","text":{"type":"plain_text","text":"Delete"},"action_id":"delete","style":"danger","confirm":{"title":{"type":"plain_text","text":"Sure?"},"text":{"type":"mrkdwn","text":"Really"},"confirm":{"type":"plain_text","text":"Yes"},"deny":{"type":"plain_text","text":"No"}}},{"type":"overflow","action_id":"more","options":[{"text":{"type":"plain_text","text":"Edit"},"value":"edit"}]}]}`,
+		},
+		{
+			"input with select",
+			Input("Env", StaticSelect("env", "Pick one").Options(Option("Prod", "prod"))).Hint("where to").Optional(),
+			`{"type":"input","label":{"type":"plain_text","text":"Env"},"element":{"type":"static_select","action_id":"env","placeholder":{"type":"plain_text","text":"Pick one"},"options":[{"text":{"type":"plain_text","text":"Prod"},"value":"prod"}]},"hint":{"type":"plain_text","text":"where to"},"optional":true}`,
+		},
+		{
+			"input with date picker",
+			Input("Day", DatePicker("day").Initial(time.Date(2020, 10, 5, 0, 0, 0, 0, time.UTC))),
+			`{"type":"input","label":{"type":"plain_text","text":"Day"},"element":{"type":"datepicker","action_id":"day","initial_date":"2020-10-05"}}`,
+		},
+		{
+			"input with text",
+			Input("Note", PlainTextInput("note").Multiline().Length(1, 100)).DispatchAction(),
+			`{"type":"input","label":{"type":"plain_text","text":"Note"},"element":{"type":"plain_text_input","action_id":"note","multiline":true,"min_length":1,"max_length":100},"dispatch_action":true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// a builder marshals to the block it builds, also without unwrapping
+			for _, b := range []slack.Block{tt.block, Blocks(tt.block)[0]} {
+				got, err := json.Marshal(b)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(got) != tt.want {
+					t.Errorf("%T =\n%s\nwant\n%s", b, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBlocksUnwrap(t *testing.T) {
+	b := Blocks(Section().PlainText("a"), &slack.DividerBlock{})
+	if _, ok := b[0].(*slack.SectionBlock); !ok {
+		t.Errorf("Blocks()[0] is %T, want *slack.SectionBlock", b[0])
+	}
+	if _, ok := b[1].(*slack.DividerBlock); !ok {
+		t.Errorf("Blocks()[1] is %T, want *slack.DividerBlock", b[1])
+	}
+	if got := Section().BlockType(); got != "section" {
+		t.Errorf("BlockType() = %q, want section", got)
+	}
+	if got := Button("a", "b").ElementType(); got != "button" {
+		t.Errorf("ElementType() = %q, want button", got)
+	}
+}
+
+func TestModal(t *testing.T) {
+	v := Modal("Deploy").Submit("Go").Close("Cancel").CallbackID("deploy").Blocks(Section().Markdown("ready?"))
+
+	want := `{"type":"modal","title":{"type":"plain_text","text":"Deploy"},"submit":{"type":"plain_text","text":"Go"},"close":{"type":"plain_text","text":"Cancel"},"blocks":[{"type":"section","text":{"type":"mrkdwn","text":"ready?"}}],"callback_id":"deploy"}`
+	for _, x := range []interface{}{v, v.View()} {
+		got, err := json.Marshal(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%T =\n%s\nwant\n%s", x, got, want)
+		}
+	}
+}
@@ -0,0 +1,1073 @@
+package blocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/txsvc/slack/pkg/slack"
+)
+
+// see https://api.slack.com/reference/block-kit/block-elements
+
+type (
+	// ButtonBuilder builds a button
+	ButtonBuilder struct {
+		element *slack.Button
+	}
+
+	// OverflowBuilder builds an overflow menu
+	OverflowBuilder struct {
+		element *slack.OverflowMenu
+	}
+
+	// StaticSelectBuilder builds a select menu with static options
+	StaticSelectBuilder struct {
+		element *slack.StaticSelect
+	}
+
+	// MultiStaticSelectBuilder builds a multi-select menu with static options
+	MultiStaticSelectBuilder struct {
+		element *slack.MultiStaticSelect
+	}
+
+	// ExternalSelectBuilder builds a select menu with options loaded from an external source
+	ExternalSelectBuilder struct {
+		element *slack.ExternalSelect
+	}
+
+	// MultiExternalSelectBuilder builds a multi-select menu with options loaded from an external source
+	MultiExternalSelectBuilder struct {
+		element *slack.MultiExternalSelect
+	}
+
+	// UsersSelectBuilder builds a select menu of users
+	UsersSelectBuilder struct {
+		element *slack.UsersSelect
+	}
+
+	// MultiUsersSelectBuilder builds a multi-select menu of users
+	MultiUsersSelectBuilder struct {
+		element *slack.MultiUsersSelect
+	}
+
+	// ConversationsSelectBuilder builds a select menu of conversations
+	ConversationsSelectBuilder struct {
+		element *slack.ConversationsSelect
+	}
+
+	// MultiConversationsSelectBuilder builds a multi-select menu of conversations
+	MultiConversationsSelectBuilder struct {
+		element *slack.MultiConversationsSelect
+	}
+
+	// ChannelsSelectBuilder builds a select menu of public channels
+	ChannelsSelectBuilder struct {
+		element *slack.ChannelsSelect
+	}
+
+	// MultiChannelsSelectBuilder builds a multi-select menu of public channels
+	MultiChannelsSelectBuilder struct {
+		element *slack.MultiChannelsSelect
+	}
+
+	// DatePickerBuilder builds a date picker
+	DatePickerBuilder struct {
+		element *slack.DatePicker
+	}
+
+	// TimePickerBuilder builds a time picker
+	TimePickerBuilder struct {
+		element *slack.TimePicker
+	}
+
+	// DateTimePickerBuilder builds a date and time picker
+	DateTimePickerBuilder struct {
+		element *slack.DateTimePicker
+	}
+
+	// PlainTextInputBuilder builds a plain text input
+	PlainTextInputBuilder struct {
+		element *slack.PlainTextInput
+	}
+
+	// EmailInputBuilder builds an email input
+	EmailInputBuilder struct {
+		element *slack.EmailInput
+	}
+
+	// URLInputBuilder builds a URL input
+	URLInputBuilder struct {
+		element *slack.URLInput
+	}
+
+	// NumberInputBuilder builds a number input
+	NumberInputBuilder struct {
+		element *slack.NumberInput
+	}
+
+	// CheckboxesBuilder builds a group of checkboxes
+	CheckboxesBuilder struct {
+		element *slack.Checkboxes
+	}
+
+	// RadioButtonsBuilder builds a group of radio buttons
+	RadioButtonsBuilder struct {
+		element *slack.Radiobuttons
+	}
+
+	// RichTextInputBuilder builds a rich text input
+	RichTextInputBuilder struct {
+		element *slack.RichTextInput
+	}
+
+	// ImageElementBuilder builds an image element, e.g. for context blocks or as accessory
+	ImageElementBuilder struct {
+		element *slack.ImageElement
+	}
+)
+
+// Button creates a button
+func Button(text, actionID string) *ButtonBuilder {
+	return &ButtonBuilder{&slack.Button{Text: PlainText(text), ActionID: actionID}}
+}
+
+// Value sets the value sent with the interaction payload
+func (b *ButtonBuilder) Value(value string) *ButtonBuilder {
+	b.element.Value = value
+	return b
+}
+
+// URL opens a link when the button is clicked
+func (b *ButtonBuilder) URL(url string) *ButtonBuilder {
+	b.element.URL = url
+	return b
+}
+
+// Primary styles the button as primary action
+func (b *ButtonBuilder) Primary() *ButtonBuilder {
+	b.element.Style = "primary"
+	return b
+}
+
+// Danger styles the button as destructive action
+func (b *ButtonBuilder) Danger() *ButtonBuilder {
+	b.element.Style = "danger"
+	return b
+}
+
+// AccessibilityLabel sets the label read by screen readers
+func (b *ButtonBuilder) AccessibilityLabel(label string) *ButtonBuilder {
+	b.element.AccessibilityLabel = label
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *ButtonBuilder) Confirm(c *slack.ConfirmObject) *ButtonBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// Element returns the element
+func (b *ButtonBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// Overflow creates an overflow menu
+func Overflow(actionID string, options ...slack.OptionsObject) *OverflowBuilder {
+	return &OverflowBuilder{&slack.OverflowMenu{ActionID: actionID, Options: options}}
+}
+
+// Confirm adds a confirmation dialog
+func (b *OverflowBuilder) Confirm(c *slack.ConfirmObject) *OverflowBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// Element returns the element
+func (b *OverflowBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// StaticSelect creates a select menu with static options
+func StaticSelect(actionID, placeholder string) *StaticSelectBuilder {
+	return &StaticSelectBuilder{&slack.StaticSelect{ActionID: actionID, Placeholder: textPtr(PlainText(placeholder))}}
+}
+
+// Initial sets the initially selected option
+func (b *StaticSelectBuilder) Initial(option slack.OptionsObject) *StaticSelectBuilder {
+	b.element.InitialOption = &option
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *StaticSelectBuilder) Confirm(c *slack.ConfirmObject) *StaticSelectBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *StaticSelectBuilder) FocusOnLoad() *StaticSelectBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Options appends options
+func (b *StaticSelectBuilder) Options(options ...slack.OptionsObject) *StaticSelectBuilder {
+	b.element.Options = append(b.element.Options, options...)
+	return b
+}
+
+// OptionGroups appends option groups
+func (b *StaticSelectBuilder) OptionGroups(groups ...slack.OptionGroupObject) *StaticSelectBuilder {
+	b.element.OptionGroups = append(b.element.OptionGroups, groups...)
+	return b
+}
+
+// Element returns the element
+func (b *StaticSelectBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// MultiStaticSelect creates a multi-select menu with static options
+func MultiStaticSelect(actionID, placeholder string) *MultiStaticSelectBuilder {
+	return &MultiStaticSelectBuilder{&slack.MultiStaticSelect{ActionID: actionID, Placeholder: textPtr(PlainText(placeholder))}}
+}
+
+// Initial sets the initially selected options
+func (b *MultiStaticSelectBuilder) Initial(options ...slack.OptionsObject) *MultiStaticSelectBuilder {
+	b.element.InitialOptions = options
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *MultiStaticSelectBuilder) Confirm(c *slack.ConfirmObject) *MultiStaticSelectBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *MultiStaticSelectBuilder) FocusOnLoad() *MultiStaticSelectBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Options appends options
+func (b *MultiStaticSelectBuilder) Options(options ...slack.OptionsObject) *MultiStaticSelectBuilder {
+	b.element.Options = append(b.element.Options, options...)
+	return b
+}
+
+// OptionGroups appends option groups
+func (b *MultiStaticSelectBuilder) OptionGroups(groups ...slack.OptionGroupObject) *MultiStaticSelectBuilder {
+	b.element.OptionGroups = append(b.element.OptionGroups, groups...)
+	return b
+}
+
+// MaxSelected limits the number of selected items
+func (b *MultiStaticSelectBuilder) MaxSelected(n int) *MultiStaticSelectBuilder {
+	b.element.MaxSelectedItems = n
+	return b
+}
+
+// Element returns the element
+func (b *MultiStaticSelectBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// ExternalSelect creates a select menu with options loaded from an external source
+func ExternalSelect(actionID, placeholder string) *ExternalSelectBuilder {
+	return &ExternalSelectBuilder{&slack.ExternalSelect{ActionID: actionID, Placeholder: textPtr(PlainText(placeholder))}}
+}
+
+// Initial sets the initially selected option
+func (b *ExternalSelectBuilder) Initial(option slack.OptionsObject) *ExternalSelectBuilder {
+	b.element.InitialOption = &option
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *ExternalSelectBuilder) Confirm(c *slack.ConfirmObject) *ExternalSelectBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *ExternalSelectBuilder) FocusOnLoad() *ExternalSelectBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// MinQueryLength sets the number of characters typed before options are requested
+func (b *ExternalSelectBuilder) MinQueryLength(n int) *ExternalSelectBuilder {
+	b.element.MinQueryLength = &n
+	return b
+}
+
+// Element returns the element
+func (b *ExternalSelectBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// MultiExternalSelect creates a multi-select menu with options loaded from an external source
+func MultiExternalSelect(actionID, placeholder string) *MultiExternalSelectBuilder {
+	return &MultiExternalSelectBuilder{&slack.MultiExternalSelect{ActionID: actionID, Placeholder: textPtr(PlainText(placeholder))}}
+}
+
+// Initial sets the initially selected options
+func (b *MultiExternalSelectBuilder) Initial(options ...slack.OptionsObject) *MultiExternalSelectBuilder {
+	b.element.InitialOptions = options
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *MultiExternalSelectBuilder) Confirm(c *slack.ConfirmObject) *MultiExternalSelectBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *MultiExternalSelectBuilder) FocusOnLoad() *MultiExternalSelectBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// MinQueryLength sets the number of characters typed before options are requested
+func (b *MultiExternalSelectBuilder) MinQueryLength(n int) *MultiExternalSelectBuilder {
+	b.element.MinQueryLength = &n
+	return b
+}
+
+// MaxSelected limits the number of selected items
+func (b *MultiExternalSelectBuilder) MaxSelected(n int) *MultiExternalSelectBuilder {
+	b.element.MaxSelectedItems = n
+	return b
+}
+
+// Element returns the element
+func (b *MultiExternalSelectBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// UsersSelect creates a select menu of users
+func UsersSelect(actionID, placeholder string) *UsersSelectBuilder {
+	return &UsersSelectBuilder{&slack.UsersSelect{ActionID: actionID, Placeholder: textPtr(PlainText(placeholder))}}
+}
+
+// Initial sets the initially selected user
+func (b *UsersSelectBuilder) Initial(userID string) *UsersSelectBuilder {
+	b.element.InitialUser = userID
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *UsersSelectBuilder) Confirm(c *slack.ConfirmObject) *UsersSelectBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *UsersSelectBuilder) FocusOnLoad() *UsersSelectBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Element returns the element
+func (b *UsersSelectBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// MultiUsersSelect creates a multi-select menu of users
+func MultiUsersSelect(actionID, placeholder string) *MultiUsersSelectBuilder {
+	return &MultiUsersSelectBuilder{&slack.MultiUsersSelect{ActionID: actionID, Placeholder: textPtr(PlainText(placeholder))}}
+}
+
+// Initial sets the initially selected users
+func (b *MultiUsersSelectBuilder) Initial(userIDs ...string) *MultiUsersSelectBuilder {
+	b.element.InitialUsers = userIDs
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *MultiUsersSelectBuilder) Confirm(c *slack.ConfirmObject) *MultiUsersSelectBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *MultiUsersSelectBuilder) FocusOnLoad() *MultiUsersSelectBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// MaxSelected limits the number of selected items
+func (b *MultiUsersSelectBuilder) MaxSelected(n int) *MultiUsersSelectBuilder {
+	b.element.MaxSelectedItems = n
+	return b
+}
+
+// Element returns the element
+func (b *MultiUsersSelectBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// ConversationsSelect creates a select menu of conversations
+func ConversationsSelect(actionID, placeholder string) *ConversationsSelectBuilder {
+	return &ConversationsSelectBuilder{&slack.ConversationsSelect{ActionID: actionID, Placeholder: textPtr(PlainText(placeholder))}}
+}
+
+// Initial sets the initially selected conversation
+func (b *ConversationsSelectBuilder) Initial(conversationID string) *ConversationsSelectBuilder {
+	b.element.InitialConversation = conversationID
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *ConversationsSelectBuilder) Confirm(c *slack.ConfirmObject) *ConversationsSelectBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *ConversationsSelectBuilder) FocusOnLoad() *ConversationsSelectBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Filter restricts the listed conversations
+func (b *ConversationsSelectBuilder) Filter(f *slack.ConversationFilter) *ConversationsSelectBuilder {
+	b.element.Filter = f
+	return b
+}
+
+// DefaultToCurrent pre-selects the conversation the view was opened from
+func (b *ConversationsSelectBuilder) DefaultToCurrent() *ConversationsSelectBuilder {
+	b.element.DefaultToCurrentConversation = true
+	return b
+}
+
+// ResponseURLEnabled includes a response_url for the selected conversation in the view submission
+func (b *ConversationsSelectBuilder) ResponseURLEnabled() *ConversationsSelectBuilder {
+	b.element.ResponseURLEnabled = true
+	return b
+}
+
+// Element returns the element
+func (b *ConversationsSelectBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// MultiConversationsSelect creates a multi-select menu of conversations
+func MultiConversationsSelect(actionID, placeholder string) *MultiConversationsSelectBuilder {
+	return &MultiConversationsSelectBuilder{&slack.MultiConversationsSelect{ActionID: actionID, Placeholder: textPtr(PlainText(placeholder))}}
+}
+
+// Initial sets the initially selected conversations
+func (b *MultiConversationsSelectBuilder) Initial(conversationIDs ...string) *MultiConversationsSelectBuilder {
+	b.element.InitialConversations = conversationIDs
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *MultiConversationsSelectBuilder) Confirm(c *slack.ConfirmObject) *MultiConversationsSelectBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *MultiConversationsSelectBuilder) FocusOnLoad() *MultiConversationsSelectBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Filter restricts the listed conversations
+func (b *MultiConversationsSelectBuilder) Filter(f *slack.ConversationFilter) *MultiConversationsSelectBuilder {
+	b.element.Filter = f
+	return b
+}
+
+// DefaultToCurrent pre-selects the conversation the view was opened from
+func (b *MultiConversationsSelectBuilder) DefaultToCurrent() *MultiConversationsSelectBuilder {
+	b.element.DefaultToCurrentConversation = true
+	return b
+}
+
+// MaxSelected limits the number of selected items
+func (b *MultiConversationsSelectBuilder) MaxSelected(n int) *MultiConversationsSelectBuilder {
+	b.element.MaxSelectedItems = n
+	return b
+}
+
+// Element returns the element
+func (b *MultiConversationsSelectBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// ChannelsSelect creates a select menu of public channels
+func ChannelsSelect(actionID, placeholder string) *ChannelsSelectBuilder {
+	return &ChannelsSelectBuilder{&slack.ChannelsSelect{ActionID: actionID, Placeholder: textPtr(PlainText(placeholder))}}
+}
+
+// Initial sets the initially selected channel
+func (b *ChannelsSelectBuilder) Initial(channelID string) *ChannelsSelectBuilder {
+	b.element.InitialChannel = channelID
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *ChannelsSelectBuilder) Confirm(c *slack.ConfirmObject) *ChannelsSelectBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *ChannelsSelectBuilder) FocusOnLoad() *ChannelsSelectBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// ResponseURLEnabled includes a response_url for the selected conversation in the view submission
+func (b *ChannelsSelectBuilder) ResponseURLEnabled() *ChannelsSelectBuilder {
+	b.element.ResponseURLEnabled = true
+	return b
+}
+
+// Element returns the element
+func (b *ChannelsSelectBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// MultiChannelsSelect creates a multi-select menu of public channels
+func MultiChannelsSelect(actionID, placeholder string) *MultiChannelsSelectBuilder {
+	return &MultiChannelsSelectBuilder{&slack.MultiChannelsSelect{ActionID: actionID, Placeholder: textPtr(PlainText(placeholder))}}
+}
+
+// Initial sets the initially selected channels
+func (b *MultiChannelsSelectBuilder) Initial(channelIDs ...string) *MultiChannelsSelectBuilder {
+	b.element.InitialChannels = channelIDs
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *MultiChannelsSelectBuilder) Confirm(c *slack.ConfirmObject) *MultiChannelsSelectBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *MultiChannelsSelectBuilder) FocusOnLoad() *MultiChannelsSelectBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// MaxSelected limits the number of selected items
+func (b *MultiChannelsSelectBuilder) MaxSelected(n int) *MultiChannelsSelectBuilder {
+	b.element.MaxSelectedItems = n
+	return b
+}
+
+// Element returns the element
+func (b *MultiChannelsSelectBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// DatePicker creates a date picker
+func DatePicker(actionID string) *DatePickerBuilder {
+	return &DatePickerBuilder{&slack.DatePicker{ActionID: actionID}}
+}
+
+// Initial sets the initially selected date
+func (b *DatePickerBuilder) Initial(t time.Time) *DatePickerBuilder {
+	b.element.InitialDate = t.Format("2006-01-02")
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *DatePickerBuilder) Confirm(c *slack.ConfirmObject) *DatePickerBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *DatePickerBuilder) FocusOnLoad() *DatePickerBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Placeholder sets the placeholder text
+func (b *DatePickerBuilder) Placeholder(text string) *DatePickerBuilder {
+	b.element.Placeholder = textPtr(PlainText(text))
+	return b
+}
+
+// Element returns the element
+func (b *DatePickerBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// TimePicker creates a time picker
+func TimePicker(actionID string) *TimePickerBuilder {
+	return &TimePickerBuilder{&slack.TimePicker{ActionID: actionID}}
+}
+
+// Initial sets the initially selected time
+func (b *TimePickerBuilder) Initial(hour, minute int) *TimePickerBuilder {
+	b.element.InitialTime = fmt.Sprintf("%02d:%02d", hour, minute)
+	return b
+}
+
+// Timezone sets the IANA timezone of the time picker
+func (b *TimePickerBuilder) Timezone(tz string) *TimePickerBuilder {
+	b.element.Timezone = tz
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *TimePickerBuilder) Confirm(c *slack.ConfirmObject) *TimePickerBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *TimePickerBuilder) FocusOnLoad() *TimePickerBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Placeholder sets the placeholder text
+func (b *TimePickerBuilder) Placeholder(text string) *TimePickerBuilder {
+	b.element.Placeholder = textPtr(PlainText(text))
+	return b
+}
+
+// Element returns the element
+func (b *TimePickerBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// DateTimePicker creates a date and time picker
+func DateTimePicker(actionID string) *DateTimePickerBuilder {
+	return &DateTimePickerBuilder{&slack.DateTimePicker{ActionID: actionID}}
+}
+
+// Initial sets the initially selected date and time
+func (b *DateTimePickerBuilder) Initial(t time.Time) *DateTimePickerBuilder {
+	b.element.InitialDateTime = t.Unix()
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *DateTimePickerBuilder) Confirm(c *slack.ConfirmObject) *DateTimePickerBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *DateTimePickerBuilder) FocusOnLoad() *DateTimePickerBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Element returns the element
+func (b *DateTimePickerBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// PlainTextInput creates a plain text input
+func PlainTextInput(actionID string) *PlainTextInputBuilder {
+	return &PlainTextInputBuilder{&slack.PlainTextInput{ActionID: actionID}}
+}
+
+// Multiline allows multiple lines of input
+func (b *PlainTextInputBuilder) Multiline() *PlainTextInputBuilder {
+	b.element.Multiline = true
+	return b
+}
+
+// Length sets the minimum and maximum length of the input, 0 means no limit
+func (b *PlainTextInputBuilder) Length(min, max int) *PlainTextInputBuilder {
+	b.element.MinLength = min
+	b.element.MaxLength = max
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *PlainTextInputBuilder) FocusOnLoad() *PlainTextInputBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Placeholder sets the placeholder text
+func (b *PlainTextInputBuilder) Placeholder(text string) *PlainTextInputBuilder {
+	b.element.Placeholder = textPtr(PlainText(text))
+	return b
+}
+
+// DispatchOn sends block_actions payloads on the given triggers
+func (b *PlainTextInputBuilder) DispatchOn(triggers ...string) *PlainTextInputBuilder {
+	b.element.DispatchActionConfig = DispatchOn(triggers...)
+	return b
+}
+
+// Initial sets the initial value
+func (b *PlainTextInputBuilder) Initial(value string) *PlainTextInputBuilder {
+	b.element.InitialValue = value
+	return b
+}
+
+// Element returns the element
+func (b *PlainTextInputBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// EmailInput creates an email input
+func EmailInput(actionID string) *EmailInputBuilder {
+	return &EmailInputBuilder{&slack.EmailInput{ActionID: actionID}}
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *EmailInputBuilder) FocusOnLoad() *EmailInputBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Placeholder sets the placeholder text
+func (b *EmailInputBuilder) Placeholder(text string) *EmailInputBuilder {
+	b.element.Placeholder = textPtr(PlainText(text))
+	return b
+}
+
+// DispatchOn sends block_actions payloads on the given triggers
+func (b *EmailInputBuilder) DispatchOn(triggers ...string) *EmailInputBuilder {
+	b.element.DispatchActionConfig = DispatchOn(triggers...)
+	return b
+}
+
+// Initial sets the initial value
+func (b *EmailInputBuilder) Initial(value string) *EmailInputBuilder {
+	b.element.InitialValue = value
+	return b
+}
+
+// Element returns the element
+func (b *EmailInputBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// URLInput creates a URL input
+func URLInput(actionID string) *URLInputBuilder {
+	return &URLInputBuilder{&slack.URLInput{ActionID: actionID}}
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *URLInputBuilder) FocusOnLoad() *URLInputBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Placeholder sets the placeholder text
+func (b *URLInputBuilder) Placeholder(text string) *URLInputBuilder {
+	b.element.Placeholder = textPtr(PlainText(text))
+	return b
+}
+
+// DispatchOn sends block_actions payloads on the given triggers
+func (b *URLInputBuilder) DispatchOn(triggers ...string) *URLInputBuilder {
+	b.element.DispatchActionConfig = DispatchOn(triggers...)
+	return b
+}
+
+// Initial sets the initial value
+func (b *URLInputBuilder) Initial(value string) *URLInputBuilder {
+	b.element.InitialValue = value
+	return b
+}
+
+// Element returns the element
+func (b *URLInputBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// NumberInput creates a number input
+func NumberInput(actionID string, decimal bool) *NumberInputBuilder {
+	return &NumberInputBuilder{&slack.NumberInput{ActionID: actionID, IsDecimalAllowed: decimal}}
+}
+
+// Range sets the minimum and maximum value, an empty string means no limit
+func (b *NumberInputBuilder) Range(min, max string) *NumberInputBuilder {
+	b.element.MinValue = min
+	b.element.MaxValue = max
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *NumberInputBuilder) FocusOnLoad() *NumberInputBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Placeholder sets the placeholder text
+func (b *NumberInputBuilder) Placeholder(text string) *NumberInputBuilder {
+	b.element.Placeholder = textPtr(PlainText(text))
+	return b
+}
+
+// DispatchOn sends block_actions payloads on the given triggers
+func (b *NumberInputBuilder) DispatchOn(triggers ...string) *NumberInputBuilder {
+	b.element.DispatchActionConfig = DispatchOn(triggers...)
+	return b
+}
+
+// Initial sets the initial value
+func (b *NumberInputBuilder) Initial(value string) *NumberInputBuilder {
+	b.element.InitialValue = value
+	return b
+}
+
+// Element returns the element
+func (b *NumberInputBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// Checkboxes creates a group of checkboxes
+func Checkboxes(actionID string, options ...slack.OptionsObject) *CheckboxesBuilder {
+	return &CheckboxesBuilder{&slack.Checkboxes{ActionID: actionID, Options: options}}
+}
+
+// Initial sets the initially checked options
+func (b *CheckboxesBuilder) Initial(options ...slack.OptionsObject) *CheckboxesBuilder {
+	b.element.InitialOptions = options
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *CheckboxesBuilder) Confirm(c *slack.ConfirmObject) *CheckboxesBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *CheckboxesBuilder) FocusOnLoad() *CheckboxesBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Element returns the element
+func (b *CheckboxesBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// RadioButtons creates a group of radio buttons
+func RadioButtons(actionID string, options ...slack.OptionsObject) *RadioButtonsBuilder {
+	return &RadioButtonsBuilder{&slack.Radiobuttons{ActionID: actionID, Options: options}}
+}
+
+// Initial sets the initially selected option
+func (b *RadioButtonsBuilder) Initial(option slack.OptionsObject) *RadioButtonsBuilder {
+	b.element.InitialOption = &option
+	return b
+}
+
+// Confirm adds a confirmation dialog
+func (b *RadioButtonsBuilder) Confirm(c *slack.ConfirmObject) *RadioButtonsBuilder {
+	b.element.Confirm = c
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *RadioButtonsBuilder) FocusOnLoad() *RadioButtonsBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Element returns the element
+func (b *RadioButtonsBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// RichTextInput creates a rich text input
+func RichTextInput(actionID string) *RichTextInputBuilder {
+	return &RichTextInputBuilder{&slack.RichTextInput{ActionID: actionID}}
+}
+
+// Initial sets the initial value
+func (b *RichTextInputBuilder) Initial(value *slack.RichTextBlock) *RichTextInputBuilder {
+	b.element.InitialValue = value
+	return b
+}
+
+// FocusOnLoad focuses the element when the view opens
+func (b *RichTextInputBuilder) FocusOnLoad() *RichTextInputBuilder {
+	b.element.FocusOnLoad = true
+	return b
+}
+
+// Placeholder sets the placeholder text
+func (b *RichTextInputBuilder) Placeholder(text string) *RichTextInputBuilder {
+	b.element.Placeholder = textPtr(PlainText(text))
+	return b
+}
+
+// DispatchOn sends block_actions payloads on the given triggers
+func (b *RichTextInputBuilder) DispatchOn(triggers ...string) *RichTextInputBuilder {
+	b.element.DispatchActionConfig = DispatchOn(triggers...)
+	return b
+}
+
+// Element returns the element
+func (b *RichTextInputBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// ImageElement creates an image element, e.g. for context blocks or as accessory
+func ImageElement(imageURL, altText string) *ImageElementBuilder {
+	return &ImageElementBuilder{&slack.ImageElement{ImageURL: imageURL, AltText: altText}}
+}
+
+// Element returns the element
+func (b *ImageElementBuilder) Element() slack.BlockElement {
+	return b.element
+}
+
+// ElementType implements slack.BlockElement
+func (b *ButtonBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *OverflowBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *StaticSelectBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *MultiStaticSelectBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *ExternalSelectBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *MultiExternalSelectBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *UsersSelectBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *MultiUsersSelectBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *ConversationsSelectBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *MultiConversationsSelectBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *ChannelsSelectBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *MultiChannelsSelectBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *DatePickerBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *TimePickerBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *DateTimePickerBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *PlainTextInputBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *EmailInputBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *URLInputBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *NumberInputBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *CheckboxesBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *RadioButtonsBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *RichTextInputBuilder) ElementType() string { return b.element.ElementType() }
+
+// ElementType implements slack.BlockElement
+func (b *ImageElementBuilder) ElementType() string { return b.element.ElementType() }
+
+// ContextElementType implements slack.ContextElement
+func (b *ImageElementBuilder) ContextElementType() string { return b.element.ContextElementType() }
+
+// MarshalJSON marshals the element
+func (b *ButtonBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *OverflowBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *StaticSelectBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *MultiStaticSelectBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *ExternalSelectBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *MultiExternalSelectBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *UsersSelectBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *MultiUsersSelectBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *ConversationsSelectBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *MultiConversationsSelectBuilder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.element)
+}
+
+// MarshalJSON marshals the element
+func (b *ChannelsSelectBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *MultiChannelsSelectBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *DatePickerBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *TimePickerBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *DateTimePickerBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *PlainTextInputBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *EmailInputBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *URLInputBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *NumberInputBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *CheckboxesBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *RadioButtonsBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *RichTextInputBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
+
+// MarshalJSON marshals the element
+func (b *ImageElementBuilder) MarshalJSON() ([]byte, error) { return json.Marshal(b.element) }
@@ -0,0 +1,78 @@
+package blocks
+
+import (
+	"github.com/txsvc/slack/pkg/slack"
+)
+
+// see https://api.slack.com/reference/block-kit/composition-objects
+
+const (
+	// PlainTextType is the type of plain text objects
+	PlainTextType = "plain_text"
+	// MarkdownType is the type of mrkdwn text objects
+	MarkdownType = "mrkdwn"
+)
+
+// PlainText creates a plain_text object
+func PlainText(text string) slack.TextObject {
+	return slack.TextObject{Type: PlainTextType, Text: text}
+}
+
+// PlainTextEmoji creates a plain_text object with emoji shortcodes enabled
+func PlainTextEmoji(text string) slack.TextObject {
+	return slack.TextObject{Type: PlainTextType, Text: text, Emoji: true}
+}
+
+// Markdown creates a mrkdwn object
+func Markdown(text string) slack.TextObject {
+	return slack.TextObject{Type: MarkdownType, Text: text}
+}
+
+// Verbatim creates a mrkdwn object without automatic link and mention parsing
+func Verbatim(text string) slack.TextObject {
+	return slack.TextObject{Type: MarkdownType, Text: text, Verbatim: true}
+}
+
+// Option creates an option with a plain_text label
+func Option(text, value string) slack.OptionsObject {
+	return slack.OptionsObject{Text: PlainText(text), Value: value}
+}
+
+// OptionWithDescription creates an option with a plain_text label and description
+func OptionWithDescription(text, value, description string) slack.OptionsObject {
+	d := PlainText(description)
+	return slack.OptionsObject{Text: PlainText(text), Value: value, Description: &d}
+}
+
+// MarkdownOption creates an option with a mrkdwn label, for checkboxes and radio buttons only
+func MarkdownOption(text, value string) slack.OptionsObject {
+	return slack.OptionsObject{Text: Markdown(text), Value: value}
+}
+
+// OptionGroup creates a group of options for selects
+func OptionGroup(label string, options ...slack.OptionsObject) slack.OptionGroupObject {
+	return slack.OptionGroupObject{Label: PlainText(label), Options: options}
+}
+
+// Confirm creates a confirmation dialog with a mrkdwn text
+func Confirm(title, text, confirm, deny string) *slack.ConfirmObject {
+	t := PlainText(title)
+	x := Markdown(text)
+	c := PlainText(confirm)
+	d := PlainText(deny)
+	return &slack.ConfirmObject{Title: &t, Text: &x, Confirm: &c, Deny: &d}
+}
+
+// Filter creates a conversation filter, include is any of im, mpim, private or public
+func Filter(include ...string) *slack.ConversationFilter {
+	return &slack.ConversationFilter{Include: include}
+}
+
+// DispatchOn creates a dispatch action config, triggers are on_enter_pressed or on_character_entered
+func DispatchOn(triggers ...string) *slack.DispatchActionConfig {
+	return &slack.DispatchActionConfig{TriggerActionsOn: triggers}
+}
+
+func textPtr(t slack.TextObject) *slack.TextObject {
+	return &t
+}
@@ -24,15 +24,17 @@ type (
 	// ViewSubmission see https://api.slack.com/reference/interaction-payloads/views#view_submission
 	// type == view_submission
 	ViewSubmission struct {
-		Type      string             `json:"type,omitempty"`
-		Team      *MessageActionTeam `json:"team,omitempty"`
-		User      *MessageActionUser `json:"user,omitempty"`
-		Token     string             `json:"token,omitempty"`
-		TriggerID string             `json:"trigger_id,omitempty"`
-		View      *ViewElement       `json:"view,omitempty"`
+		Type                string                   `json:"type,omitempty"`
+		Team                *MessageActionTeam       `json:"team,omitempty"`
+		Enterprise          *MessageActionEnterprise `json:"enterprise,omitempty"`
+		IsEnterpriseInstall bool                     `json:"is_enterprise_install,omitempty"`
+		User                *MessageActionUser       `json:"user,omitempty"`
+		Token               string                   `json:"token,omitempty"`
+		TriggerID           string                   `json:"trigger_id,omitempty"`
+		View                *ViewElement             `json:"view,omitempty"`
 	}
 
-	// message_action -> ActionRequest
+	// message_action, shortcut -> ActionRequest
 	// view_submission -> ViewSubmission
 
 	// ActionRequestPeek is used to determin the type of request
@@ -43,20 +45,23 @@ type (
 	// See https://api.slack.com/reference/interaction-payloads/actions
 
 	// ActionRequest is the payload received from Slack when the user triggers a custom message action
-	// type == message_action
+	// or a global shortcut. Shortcuts have no channel, message and response_url.
+	// type == message_action or shortcut
 	ActionRequest struct {
-		Type             string                `json:"type,omitempty"`
-		Token            string                `json:"token,omitempty"`
-		ActionTimestamp  string                `json:"action_ts,omitempty"`
-		Team             *MessageActionTeam    `json:"team,omitempty"`
-		User             *MessageActionUser    `json:"user,omitempty"`
-		Channel          *MessageActionChannel `json:"channel,omitempty"`
-		CallbackID       string                `json:"callback_id,omitempty"`
-		TriggerID        string                `json:"trigger_id,omitempty"`
-		MessageTimestamp string                `json:"message_ts,omitempty"`
-		Message          *ActionRequestMessage `json:"message,omitempty"`
-		ResponseURL      string                `json:"response_url,omitempty"`
-		Submission       map[string]string     `json:"submission,omitempty"`
+		Type                string                   `json:"type,omitempty"`
+		Token               string                   `json:"token,omitempty"`
+		ActionTimestamp     string                   `json:"action_ts,omitempty"`
+		Team                *MessageActionTeam       `json:"team,omitempty"`
+		Enterprise          *MessageActionEnterprise `json:"enterprise,omitempty"`
+		IsEnterpriseInstall bool                     `json:"is_enterprise_install,omitempty"`
+		User                *MessageActionUser       `json:"user,omitempty"`
+		Channel             *MessageActionChannel    `json:"channel,omitempty"`
+		CallbackID          string                   `json:"callback_id,omitempty"`
+		TriggerID           string                   `json:"trigger_id,omitempty"`
+		MessageTimestamp    string                   `json:"message_ts,omitempty"`
+		Message             *ActionRequestMessage    `json:"message,omitempty"`
+		ResponseURL         string                   `json:"response_url,omitempty"`
+		Submission          map[string]string        `json:"submission,omitempty"`
 	}
 
 	// ActionRequestMessage is the message's main content
@@ -65,6 +70,7 @@ type (
 		User         string                     `json:"user,omitempty"`
 		Text         string                     `json:"text,omitempty"`
 		Attachements []ActionRequestAttachement `json:"attachments,omitempty"`
+		Blocks       []Block                    `json:"blocks,omitempty"`
 		Timestamp    string                     `json:"ts,omitempty"`
 	}
 
@@ -91,6 +97,12 @@ type (
 		Domain string `json:"domain,omitempty"`
 	}
 
+	// MessageActionEnterprise identifies the Enterprise Grid organization the message originates from
+	MessageActionEnterprise struct {
+		ID   string `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+	}
+
 	// MessageActionUser identifies the user who triggered the custom action
 	MessageActionUser struct {
 		ID   string `json:"id,omitempty"`
@@ -108,50 +120,71 @@ type (
 var startActionLookup map[string]StartActionFunc
 var completeActionLookup map[string]CompleteActionFunc
 
-// ActionRequestEndpoint receives callbacks from Slack
+// ActionRequestEndpoint receives callbacks from Slack. Message actions and global shortcuts are
+// dispatched to the start action registered for their callback_id, view submissions to the
+// completion action. Handler errors are answered with status 200 and a message for the user,
+// malformed payloads with status 400. block_actions are acknowledged but not dispatched, this
+// package has no handlers for interactive components in messages.
 func ActionRequestEndpoint(c *gin.Context) {
 	var peek ActionRequestPeek
 
 	err := json.Unmarshal([]byte(c.Request.FormValue("payload")), &peek)
 	if err != nil {
 		platform.ReportError(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "msg": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "msg": err.Error()})
 		return
 	}
 
-	if peek.Type == "message_action" {
+	if peek.Type == "message_action" || peek.Type == "shortcut" {
 		var action ActionRequest
 		err := json.Unmarshal([]byte(c.Request.FormValue("payload")), &action)
 		if err != nil {
 			platform.ReportError(err)
-			c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "msg": err.Error()})
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "msg": err.Error()})
 			return
 		}
 
 		err = startAction(c, &action)
 		if err != nil {
-			platform.ReportError(err)
-			c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "msg": err.Error()})
-			return
+			i := &Interaction{Type: InteractionMessageAction, Name: action.CallbackID, Action: &action}
+			msg := handleError(c, i, err)
+			if err := respondToAction(appengine.NewContext(c.Request), &action, "Something went wrong", msg); err != nil {
+				platform.ReportError(err)
+			}
 		}
+		c.Status(http.StatusOK)
 
 	} else if peek.Type == "view_submission" {
 		var submission ViewSubmission
 		err := json.Unmarshal([]byte(c.Request.FormValue("payload")), &submission)
 		if err != nil {
 			platform.ReportError(err)
-			c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "msg": err.Error()})
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "msg": err.Error()})
 			return
 		}
 
-		err = completeAction(c, &submission)
+		resp, err := completeAction(c, &submission)
 		if err != nil {
-			platform.ReportError(err)
-			c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "msg": err.Error()})
+			i := &Interaction{Type: InteractionViewSubmission, Submission: &submission}
+			if submission.View != nil {
+				i.Name = submission.View.CallbackID
+			}
+			msg := handleError(c, i, err)
+			c.JSON(http.StatusOK, viewResponse{ResponseAction: "push", View: messageView("Something went wrong", msg)})
 			return
 		}
+		if resp != nil {
+			// middleware answered instead of the handler, e.g. a denial. Keep the modal open and show the message.
+			c.JSON(http.StatusOK, viewResponse{ResponseAction: "push", View: messageView("Sorry", resp)})
+			return
+		}
+		c.Status(http.StatusOK)
+
+	} else if peek.Type == "block_actions" {
+		c.Status(http.StatusOK) // acknowledge, see above
 	} else {
 		platform.ReportError(fmt.Errorf("Unknown action request: '%s'", peek.Type))
+		c.Status(http.StatusOK)
 	}
 }
 
@@ -165,9 +198,11 @@ func RegisterCompleteAction(action string, h CompleteActionFunc) {
 	completeActionLookup[strings.ToLower(action)] = h
 }
 
-// StoreActionCorrelation is a helper to mange correlation keys
-func StoreActionCorrelation(ctx context.Context, action, viewID, teamID string) error {
-	err := s.SetKV(ctx, correlationKey(viewID, teamID), strings.ToLower(action), 1800)
+// StoreActionCorrelation is a helper to mange correlation keys. The clientID is the ID the
+// installation is stored under, use ActionRequest.ClientID(). It is the team ID, except for
+// org-wide installs on Enterprise Grid.
+func StoreActionCorrelation(ctx context.Context, action, viewID, clientID string) error {
+	err := s.SetKV(ctx, correlationKey(viewID, clientID), strings.ToLower(action), 1800)
 	if err != nil {
 		platform.ReportError(err)
 	}
@@ -182,34 +217,137 @@ func startAction(c *gin.Context, a *ActionRequest) error {
 		return fmt.Errorf(fmt.Sprintf("No handler for action request '%s'", action))
 	}
 
-	return handler(c, a)
+	i := &Interaction{Type: InteractionMessageAction, Name: action, Action: a}
+	resp, err := runInteraction(c, i, func(c *gin.Context, i *Interaction) (*SectionBlocks, error) {
+		return nil, handler(c, i.Action)
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp != nil {
+		return respondToAction(appengine.NewContext(c.Request), a, "Sorry", resp)
+	}
+	return nil
 }
 
-// completeAction starts the processing of the action's result
-func completeAction(c *gin.Context, s *ViewSubmission) error {
+// completeAction starts the processing of the action's result. A message is returned if
+// middleware answered the submission instead of the handler.
+func completeAction(c *gin.Context, s *ViewSubmission) (*SectionBlocks, error) {
 	ctx := appengine.NewContext(c.Request)
 
-	action := lookupActionCorrelation(ctx, s.View.ID, s.Team.ID)
+	action := lookupActionCorrelation(ctx, s.View.ID, s.ClientID())
+	if action == "" && s.TeamID() != "" && s.TeamID() != s.ClientID() {
+		// stored with the team ID of an org-wide install
+		action = lookupActionCorrelation(ctx, s.View.ID, s.TeamID())
+	}
 	if action == "" {
-		return nil
+		return nil, nil
 	}
 
 	handler := completeActionLookup[action]
 	if handler == nil {
-		return fmt.Errorf("No handler for action response '%s'", action)
+		return nil, fmt.Errorf("No handler for action response '%s'", action)
+	}
+
+	i := &Interaction{Type: InteractionViewSubmission, Name: action, Submission: s}
+	return runInteraction(c, i, func(c *gin.Context, i *Interaction) (*SectionBlocks, error) {
+		return nil, handler(c, i.Submission)
+	})
+}
+
+// respondToAction sends a message to the user who triggered an action. Actions without a
+// response_url, e.g. shortcuts, get an ephemeral message in the channel or, if there is no
+// channel, a modal opened with the trigger ID.
+func respondToAction(ctx context.Context, a *ActionRequest, title string, msg *SectionBlocks) error {
+	if a.ResponseURL != "" {
+		return Respond(ctx, a.ResponseURL, msg)
+	}
+
+	enterpriseID := ""
+	if a.Enterprise != nil {
+		enterpriseID = a.Enterprise.ID
+	}
+	token, err := GetToken(ctx, a.TeamID(), enterpriseID)
+	if err != nil {
+		return err
 	}
 
-	return handler(c, s)
+	if a.Channel != nil && a.Channel.ID != "" && a.User != nil {
+		resp, err := Post(ctx, token, "chat.postEphemeral", &EphemeralMessage{
+			Channel: a.Channel.ID,
+			User:    a.User.ID,
+			Text:    msg.Text,
+			Blocks:  msg.Blocks,
+		})
+		if err != nil {
+			return err
+		}
+		if !resp.OK {
+			return fmt.Errorf("chat.postEphemeral: %s", resp.Error)
+		}
+		return nil
+	}
+
+	if a.TriggerID == "" {
+		return fmt.Errorf("no way to respond to action '%s'", a.CallbackID)
+	}
+
+	var resp ModalResponse
+	if err := CustomPost(ctx, token, "views.open", &ModalRequest{TriggerID: a.TriggerID, View: *messageView(title, msg)}, &resp); err != nil {
+		return err
+	}
+	if !resp.OK {
+		return fmt.Errorf("views.open: %s", resp.Error)
+	}
+	return nil
+}
+
+// TeamID returns the ID of the team the action was triggered in, if any
+func (a *ActionRequest) TeamID() string {
+	return teamID(a.Team)
+}
+
+// ClientID returns the ID the installation handling the action is stored under,
+// i.e. the enterprise ID for org-wide installs and the team ID otherwise
+func (a *ActionRequest) ClientID() string {
+	return clientID(a.Team, a.Enterprise, a.IsEnterpriseInstall)
+}
+
+// TeamID returns the ID of the team the view was submitted in, if any
+func (s *ViewSubmission) TeamID() string {
+	return teamID(s.Team)
+}
+
+// ClientID returns the ID the installation handling the submission is stored under,
+// i.e. the enterprise ID for org-wide installs and the team ID otherwise
+func (s *ViewSubmission) ClientID() string {
+	return clientID(s.Team, s.Enterprise, s.IsEnterpriseInstall)
+}
+
+func teamID(t *MessageActionTeam) string {
+	if t == nil {
+		return ""
+	}
+	return t.ID
+}
+
+// clientID handles payloads from org-wide installs, where team can be null while enterprise is present
+func clientID(t *MessageActionTeam, e *MessageActionEnterprise, enterpriseInstall bool) string {
+	if e != nil && (enterpriseInstall || t == nil) {
+		return e.ID
+	}
+	return teamID(t)
 }
 
-func lookupActionCorrelation(ctx context.Context, viewID, teamID string) string {
-	v, err := s.GetKV(ctx, correlationKey(viewID, teamID))
+func lookupActionCorrelation(ctx context.Context, viewID, clientID string) string {
+	v, err := s.GetKV(ctx, correlationKey(viewID, clientID))
 	if err != nil {
 		return ""
 	}
 	return v
 }
 
-func correlationKey(viewID, teamID string) string {
-	return viewID + "." + teamID
+func correlationKey(viewID, clientID string) string {
+	return viewID + "." + clientID
 }
@@ -0,0 +1,493 @@
+package slack
+
+import (
+	"encoding/json"
+)
+
+// see https://api.slack.com/reference/block-kit
+
+type (
+	// Block is implemented by all layout blocks. Messages, modals and Home tabs use the same blocks.
+	Block interface {
+		BlockType() string
+	}
+
+	// BlockElement is implemented by all block elements, e.g. as accessory of a SectionBlock
+	// or as element of an InputBlock
+	BlockElement interface {
+		ElementType() string
+	}
+
+	// ContextElement is implemented by the elements of a ContextBlock, i.e. text objects and images
+	ContextElement interface {
+		ContextElementType() string
+	}
+
+	// UnknownBlock holds a block this package does not know about, or could not decode.
+	// The raw JSON is preserved so that the block can be sent back to Slack unchanged.
+	UnknownBlock struct {
+		Type string
+		Raw  json.RawMessage
+	}
+
+	// UnknownElement holds a block element this package does not know about, see UnknownBlock
+	UnknownElement struct {
+		Type string
+		Raw  json.RawMessage
+	}
+
+	typePeek struct {
+		Type string `json:"type"`
+	}
+)
+
+// blockTypes and elementTypes map the type of a block or element to its implementation
+var blockTypes = map[string]func() Block{
+	"section":   func() Block { return &SectionBlock{} },
+	"divider":   func() Block { return &DividerBlock{} },
+	"input":     func() Block { return &InputBlock{} },
+	"header":    func() Block { return &HeaderBlock{} },
+	"context":   func() Block { return &ContextBlock{} },
+	"image":     func() Block { return &ImageBlock{} },
+	"actions":   func() Block { return &ActionsBlock{} },
+	"file":      func() Block { return &FileBlock{} },
+	"video":     func() Block { return &VideoBlock{} },
+	"rich_text": func() Block { return &RichTextBlock{} },
+}
+
+var elementTypes = map[string]func() BlockElement{
+	"checkboxes":                 func() BlockElement { return &Checkboxes{} },
+	"radio_buttons":              func() BlockElement { return &Radiobuttons{} },
+	"image":                      func() BlockElement { return &ImageElement{} },
+	"button":                     func() BlockElement { return &Button{} },
+	"overflow":                   func() BlockElement { return &OverflowMenu{} },
+	"static_select":              func() BlockElement { return &StaticSelect{} },
+	"multi_static_select":        func() BlockElement { return &MultiStaticSelect{} },
+	"external_select":            func() BlockElement { return &ExternalSelect{} },
+	"multi_external_select":      func() BlockElement { return &MultiExternalSelect{} },
+	"users_select":               func() BlockElement { return &UsersSelect{} },
+	"multi_users_select":         func() BlockElement { return &MultiUsersSelect{} },
+	"conversations_select":       func() BlockElement { return &ConversationsSelect{} },
+	"multi_conversations_select": func() BlockElement { return &MultiConversationsSelect{} },
+	"channels_select":            func() BlockElement { return &ChannelsSelect{} },
+	"multi_channels_select":      func() BlockElement { return &MultiChannelsSelect{} },
+	"datepicker":                 func() BlockElement { return &DatePicker{} },
+	"timepicker":                 func() BlockElement { return &TimePicker{} },
+	"datetimepicker":             func() BlockElement { return &DateTimePicker{} },
+	"plain_text_input":           func() BlockElement { return &PlainTextInput{} },
+	"email_text_input":           func() BlockElement { return &EmailInput{} },
+	"url_text_input":             func() BlockElement { return &URLInput{} },
+	"rich_text_input":            func() BlockElement { return &RichTextInput{} },
+	"number_input":               func() BlockElement { return &NumberInput{} },
+}
+
+// BlockType implements Block
+func (b SectionBlock) BlockType() string { return "section" }
+
+// BlockType implements Block
+func (b DividerBlock) BlockType() string { return "divider" }
+
+// BlockType implements Block
+func (b InputBlock) BlockType() string { return "input" }
+
+// BlockType implements Block
+func (b HeaderBlock) BlockType() string { return "header" }
+
+// BlockType implements Block
+func (b ContextBlock) BlockType() string { return "context" }
+
+// BlockType implements Block
+func (b ImageBlock) BlockType() string { return "image" }
+
+// BlockType implements Block
+func (b ActionsBlock) BlockType() string { return "actions" }
+
+// BlockType implements Block
+func (b FileBlock) BlockType() string { return "file" }
+
+// BlockType implements Block
+func (b VideoBlock) BlockType() string { return "video" }
+
+// BlockType implements Block
+func (b UnknownBlock) BlockType() string { return b.Type }
+
+// ElementType implements BlockElement
+func (e UnknownElement) ElementType() string { return e.Type }
+
+// ElementType implements BlockElement
+func (e Checkboxes) ElementType() string { return "checkboxes" }
+
+// ElementType implements BlockElement
+func (e Radiobuttons) ElementType() string { return "radio_buttons" }
+
+// ElementType implements BlockElement
+func (e ImageElement) ElementType() string { return "image" }
+
+// ContextElementType implements ContextElement
+func (e UnknownElement) ContextElementType() string { return e.Type }
+
+// ContextElementType implements ContextElement
+func (e ImageElement) ContextElementType() string { return "image" }
+
+// ContextElementType implements ContextElement
+func (t TextObject) ContextElementType() string { return t.Type }
+
+// MarshalJSON sets the block type
+func (b SectionBlock) MarshalJSON() ([]byte, error) {
+	type alias SectionBlock
+	b.Type = b.BlockType()
+	return json.Marshal(alias(b))
+}
+
+// MarshalJSON sets the block type
+func (b DividerBlock) MarshalJSON() ([]byte, error) {
+	type alias DividerBlock
+	b.Type = b.BlockType()
+	return json.Marshal(alias(b))
+}
+
+// MarshalJSON sets the block type
+func (b InputBlock) MarshalJSON() ([]byte, error) {
+	type alias InputBlock
+	b.Type = b.BlockType()
+	return json.Marshal(alias(b))
+}
+
+// MarshalJSON sets the block type
+func (b HeaderBlock) MarshalJSON() ([]byte, error) {
+	type alias HeaderBlock
+	b.Type = b.BlockType()
+	return json.Marshal(alias(b))
+}
+
+// MarshalJSON sets the block type
+func (b ContextBlock) MarshalJSON() ([]byte, error) {
+	type alias ContextBlock
+	b.Type = b.BlockType()
+	return json.Marshal(alias(b))
+}
+
+// MarshalJSON sets the block type
+func (b ImageBlock) MarshalJSON() ([]byte, error) {
+	type alias ImageBlock
+	b.Type = b.BlockType()
+	return json.Marshal(alias(b))
+}
+
+// MarshalJSON sets the block type
+func (b ActionsBlock) MarshalJSON() ([]byte, error) {
+	type alias ActionsBlock
+	b.Type = b.BlockType()
+	return json.Marshal(alias(b))
+}
+
+// MarshalJSON sets the block type
+func (b FileBlock) MarshalJSON() ([]byte, error) {
+	type alias FileBlock
+	b.Type = b.BlockType()
+	if b.Source == "" {
+		b.Source = "remote"
+	}
+	return json.Marshal(alias(b))
+}
+
+// MarshalJSON sets the block type
+func (b VideoBlock) MarshalJSON() ([]byte, error) {
+	type alias VideoBlock
+	b.Type = b.BlockType()
+	return json.Marshal(alias(b))
+}
+
+// MarshalJSON returns the block as it was received
+func (b UnknownBlock) MarshalJSON() ([]byte, error) {
+	if b.Raw == nil {
+		return []byte("null"), nil
+	}
+	return b.Raw, nil
+}
+
+// MarshalJSON returns the element as it was received
+func (e UnknownElement) MarshalJSON() ([]byte, error) {
+	if e.Raw == nil {
+		return []byte("null"), nil
+	}
+	return e.Raw, nil
+}
+
+// MarshalJSON sets the element type
+func (e Checkboxes) MarshalJSON() ([]byte, error) {
+	type alias Checkboxes
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e Radiobuttons) MarshalJSON() ([]byte, error) {
+	type alias Radiobuttons
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e ImageElement) MarshalJSON() ([]byte, error) {
+	type alias ImageElement
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// UnmarshalJSON decodes the elements of a context block
+func (b *ContextBlock) UnmarshalJSON(data []byte) error {
+	type alias ContextBlock
+	aux := struct {
+		*alias
+		Elements []json.RawMessage `json:"elements"`
+	}{alias: (*alias)(b)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	elements, err := decodeContextElements(aux.Elements)
+	if err != nil {
+		return err
+	}
+	b.Elements = elements
+	return nil
+}
+
+// UnmarshalJSON decodes the elements of an actions block
+func (b *ActionsBlock) UnmarshalJSON(data []byte) error {
+	type alias ActionsBlock
+	aux := struct {
+		*alias
+		Elements []json.RawMessage `json:"elements"`
+	}{alias: (*alias)(b)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	elements, err := decodeElements(aux.Elements)
+	if err != nil {
+		return err
+	}
+	b.Elements = elements
+	return nil
+}
+
+// UnmarshalJSON decodes the accessory of a section
+func (b *SectionBlock) UnmarshalJSON(data []byte) error {
+	type alias SectionBlock
+	aux := struct {
+		*alias
+		Accessory json.RawMessage `json:"accessory,omitempty"`
+	}{alias: (*alias)(b)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	e, err := decodeElement(aux.Accessory)
+	if err != nil {
+		return err
+	}
+	b.Accessory = e
+	return nil
+}
+
+// UnmarshalJSON decodes the element of an input block
+func (b *InputBlock) UnmarshalJSON(data []byte) error {
+	type alias InputBlock
+	aux := struct {
+		*alias
+		Element json.RawMessage `json:"element"`
+	}{alias: (*alias)(b)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	e, err := decodeElement(aux.Element)
+	if err != nil {
+		return err
+	}
+	b.Element = e
+	return nil
+}
+
+// UnmarshalJSON decodes the blocks of a view
+func (v *ViewElement) UnmarshalJSON(data []byte) error {
+	type alias ViewElement
+	aux := struct {
+		*alias
+		Blocks []json.RawMessage `json:"blocks"`
+	}{alias: (*alias)(v)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	blocks := decodeBlocks(aux.Blocks)
+	v.Blocks = blocks
+	return nil
+}
+
+// UnmarshalJSON decodes the blocks of a message
+func (m *SectionBlocks) UnmarshalJSON(data []byte) error {
+	type alias SectionBlocks
+	aux := struct {
+		*alias
+		Blocks []json.RawMessage `json:"blocks"`
+	}{alias: (*alias)(m)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	blocks := decodeBlocks(aux.Blocks)
+	m.Blocks = blocks
+	return nil
+}
+
+// UnmarshalJSON decodes the blocks of an attachment
+func (a *MessageAttachment) UnmarshalJSON(data []byte) error {
+	type alias MessageAttachment
+	aux := struct {
+		*alias
+		Blocks []json.RawMessage `json:"blocks,omitempty"`
+	}{alias: (*alias)(a)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	blocks := decodeBlocks(aux.Blocks)
+	a.Blocks = blocks
+	return nil
+}
+
+// UnmarshalJSON decodes the blocks of a message received with an action request
+func (m *ActionRequestMessage) UnmarshalJSON(data []byte) error {
+	type alias ActionRequestMessage
+	aux := struct {
+		*alias
+		Blocks []json.RawMessage `json:"blocks,omitempty"`
+	}{alias: (*alias)(m)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	blocks := decodeBlocks(aux.Blocks)
+	m.Blocks = blocks
+	return nil
+}
+
+// decodeBlocks decodes a list of blocks. A block that can not be decoded is kept as UnknownBlock,
+// so that a single unexpected block does not fail the whole payload.
+func decodeBlocks(raw []json.RawMessage) []Block {
+	if raw == nil {
+		return nil
+	}
+
+	blocks := make([]Block, len(raw))
+	for i := range raw {
+		b, err := decodeBlock(raw[i])
+		if err != nil {
+			var peek typePeek
+			json.Unmarshal(raw[i], &peek)
+			b = &UnknownBlock{Type: peek.Type, Raw: append(json.RawMessage{}, raw[i]...)}
+		}
+		blocks[i] = b
+	}
+	return blocks
+}
+
+// decodeBlock dispatches on the block type, unknown blocks are preserved as UnknownBlock
+func decodeBlock(raw json.RawMessage) (Block, error) {
+	var peek typePeek
+	if err := json.Unmarshal(raw, &peek); err != nil {
+		return nil, err
+	}
+
+	newBlock, ok := blockTypes[peek.Type]
+	if !ok {
+		return &UnknownBlock{Type: peek.Type, Raw: append(json.RawMessage{}, raw...)}, nil
+	}
+
+	b := newBlock()
+	if err := json.Unmarshal(raw, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func decodeElements(raw []json.RawMessage) ([]BlockElement, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	elements := make([]BlockElement, len(raw))
+	for i := range raw {
+		e, err := decodeElement(raw[i])
+		if err != nil {
+			return nil, err
+		}
+		elements[i] = e
+	}
+	return elements, nil
+}
+
+// decodeContextElements decodes text objects and images, other elements are preserved as UnknownElement
+func decodeContextElements(raw []json.RawMessage) ([]ContextElement, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	elements := make([]ContextElement, len(raw))
+	for i := range raw {
+		var peek typePeek
+		if err := json.Unmarshal(raw[i], &peek); err != nil {
+			return nil, err
+		}
+
+		var e ContextElement
+		switch peek.Type {
+		case "plain_text", "mrkdwn":
+			e = &TextObject{}
+		case "image":
+			e = &ImageElement{}
+		default:
+			elements[i] = &UnknownElement{Type: peek.Type, Raw: append(json.RawMessage{}, raw[i]...)}
+			continue
+		}
+		if err := json.Unmarshal(raw[i], e); err != nil {
+			return nil, err
+		}
+		elements[i] = e
+	}
+	return elements, nil
+}
+
+// decodeElement dispatches on the element type, unknown elements are preserved as UnknownElement
+func decodeElement(raw json.RawMessage) (BlockElement, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var peek typePeek
+	if err := json.Unmarshal(raw, &peek); err != nil {
+		return nil, err
+	}
+
+	newElement, ok := elementTypes[peek.Type]
+	if !ok {
+		return &UnknownElement{Type: peek.Type, Raw: append(json.RawMessage{}, raw...)}, nil
+	}
+
+	e := newElement()
+	if err := json.Unmarshal(raw, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -0,0 +1,108 @@
+package slack
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestBlocksRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		block string
+		want  Block // type of the decoded block
+	}{
+		{"section with accessory", `{"type":"section","block_id":"s","text":{"type":"mrkdwn","text":"*hi*"},"accessory":{"type":"button","text":{"type":"plain_text","text":"Go"},"action_id":"go","value":"1","style":"primary"}}`, &SectionBlock{}},
+		{"section with fields", `{"type":"section","text":{"type":"plain_text","text":"hi","emoji":true},"fields":[{"type":"mrkdwn","text":"a"},{"type":"mrkdwn","text":"b"}]}`, &SectionBlock{}},
+		{"divider", `{"type":"divider","block_id":"d"}`, &DividerBlock{}},
+		{"header", `{"type":"header","text":{"type":"plain_text","text":"Title"}}`, &HeaderBlock{}},
+		{"context", `{"type":"context","elements":[{"type":"mrkdwn","text":"a"},{"type":"image","image_url":"https://example.com/a.png","alt_text":"a"},{"type":"future_element","x":1}]}`, &ContextBlock{}},
+		{"actions", `{"type":"actions","elements":[{"type":"static_select","action_id":"pick","options":[{"text":{"type":"plain_text","text":"A"},"value":"a"}]},{"type":"datepicker","action_id":"when"},{"type":"future_element","action_id":"x"}]}`, &ActionsBlock{}},
+		{"input", `{"type":"input","block_id":"i","label":{"type":"plain_text","text":"Name"},"element":{"type":"plain_text_input","action_id":"name","multiline":true},"optional":true}`, &InputBlock{}},
+		{"image", `{"type":"image","image_url":"https://example.com/a.png","alt_text":"a"}`, &ImageBlock{}},
+		{"unknown block", `{"type":"future_block","block_id":"f","payload":{"a":[1,2]}}`, &UnknownBlock{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var msg SectionBlocks
+			if err := json.Unmarshal([]byte(`{"blocks":[`+tt.block+`]}`), &msg); err != nil {
+				t.Fatal(err)
+			}
+			if len(msg.Blocks) != 1 || reflect.TypeOf(msg.Blocks[0]) != reflect.TypeOf(tt.want) {
+				t.Fatalf("decoded %T, want %T", msg.Blocks, tt.want)
+			}
+
+			data, err := json.Marshal(msg.Blocks[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got, want interface{}
+			json.Unmarshal(data, &got)
+			json.Unmarshal([]byte(tt.block), &want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip = %s, want %s", data, tt.block)
+			}
+		})
+	}
+}
+
+func TestDecodeElements(t *testing.T) {
+	var b ActionsBlock
+	if err := json.Unmarshal([]byte(`{"type":"actions","elements":[{"type":"button","text":{"type":"plain_text","text":"Go"}},{"type":"users_select","action_id":"u"},{"type":"future_element"}]}`), &b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []BlockElement{&Button{}, &UsersSelect{}, &UnknownElement{}}
+	for i := range want {
+		if reflect.TypeOf(b.Elements[i]) != reflect.TypeOf(want[i]) {
+			t.Errorf("elements[%d] = %T, want %T", i, b.Elements[i], want[i])
+		}
+	}
+
+	var c ContextBlock
+	if err := json.Unmarshal([]byte(`{"type":"context","elements":[{"type":"plain_text","text":"a"},{"type":"image","image_url":"x","alt_text":"x"}]}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Elements[0].(*TextObject); !ok {
+		t.Errorf("context elements[0] = %T, want *TextObject", c.Elements[0])
+	}
+	if _, ok := c.Elements[1].(*ImageElement); !ok {
+		t.Errorf("context elements[1] = %T, want *ImageElement", c.Elements[1])
+	}
+}
+
+func TestDecodeMalformedBlock(t *testing.T) {
+	payload := `{"blocks":[{"type":"divider"},{"type":"section","text":"not an object"},{"type":"header","text":{"type":"plain_text","text":"Title"}},{"type":7}]}`
+
+	var msg SectionBlocks
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		t.Fatalf("one malformed block failed the whole message: %v", err)
+	}
+
+	want := []Block{&DividerBlock{}, &UnknownBlock{}, &HeaderBlock{}, &UnknownBlock{}}
+	if len(msg.Blocks) != len(want) {
+		t.Fatalf("decoded %d blocks, want %d", len(msg.Blocks), len(want))
+	}
+	for i := range want {
+		if reflect.TypeOf(msg.Blocks[i]) != reflect.TypeOf(want[i]) {
+			t.Errorf("blocks[%d] = %T, want %T", i, msg.Blocks[i], want[i])
+		}
+	}
+	if u := msg.Blocks[1].(*UnknownBlock); u.Type != "section" {
+		t.Errorf("blocks[1].Type = %q, want section", u.Type)
+	}
+
+	// the malformed blocks are sent back unchanged
+	data, err := json.Marshal(msg.Blocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got, orig struct{ Blocks []interface{} }
+	json.Unmarshal([]byte(`{"blocks":`+string(data)+`}`), &got)
+	json.Unmarshal([]byte(payload), &orig)
+	if !reflect.DeepEqual(got, orig) {
+		t.Errorf("round trip = %s, want %s", data, payload)
+	}
+}
@@ -12,30 +12,25 @@ import (
 // see https://api.slack.com/interactivity/slash-commands
 
 type (
-	// SlashCommandFunc handles a slash command
+	// SlashCommandFunc handles a slash command. The message returned is the response, nil acknowledges the command without one.
 	SlashCommandFunc func(c *gin.Context, cmd *SlashCommand) (*SectionBlocks, error)
 
 	// SlashCommand encapsulates the payload sent from Slack as a result of invoking a /slash command
 	SlashCommand struct {
-		TeamID         string
-		TeamDomain     string
-		EnterpriseID   string
-		EnterpriseName string
-		ChannelID      string
-		ChannelName    string
-		UserID         string
-		UserName       string
-		Command        string
-		Txt            string
-		ResponseURL    string
-		TriggerID      string
-		Token          string // DEPRECATED
-	}
-
-	cmdErrorWrapper struct {
-		err error
-		cmd *SlashCommand
-		msg string
+		TeamID              string
+		TeamDomain          string
+		EnterpriseID        string
+		EnterpriseName      string
+		IsEnterpriseInstall bool
+		ChannelID           string
+		ChannelName         string
+		UserID              string
+		UserName            string
+		Command             string
+		Txt                 string
+		ResponseURL         string
+		TriggerID           string
+		Token               string // DEPRECATED
 	}
 )
 
@@ -44,8 +39,6 @@ var defaultSlashCommandHandler SlashCommandFunc
 
 // SlashCmdEndpoint receives callbacks from Slack command /lnkk
 func SlashCmdEndpoint(c *gin.Context) {
-	status := http.StatusOK
-
 	// extract the cmd and react on it
 	cmd := GetSlashCommand(c)
 
@@ -56,40 +49,71 @@ func SlashCmdEndpoint(c *gin.Context) {
 		platform.ReportError(e)
 
 		handler = defaultSlashCommandHandler
-		status = http.StatusBadRequest
 	}
 
-	resp, err := handler(c, cmd)
+	i := &Interaction{Type: InteractionSlashCommand, Name: cmd.Command, Command: cmd}
+	resp, err := runInteraction(c, i, func(c *gin.Context, i *Interaction) (*SectionBlocks, error) {
+		return handler(c, i.Command)
+	})
 
 	if err != nil {
-		status = http.StatusOK
+		// Slack only shows the response with status 200
+		msg := handleError(c, i, err)
 		if resp == nil {
-			resp = genericErrorSectionBlock(c, cmd)
+			resp = msg
 		}
 	}
 
-	c.JSON(status, resp)
+	if resp == nil {
+		// an empty response acknowledges the command without a message
+		c.Status(http.StatusOK)
+		return
+	}
+	if resp.Text == "" {
+		resp.Text = fallbackText(resp.Blocks)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// NewInChannelResponse creates a response that is visible to all members of the channel
+func NewInChannelResponse(text string, blocks ...Block) *SectionBlocks {
+	return &SectionBlocks{ResponseType: SlackResponseTypeChannel, Text: text, Blocks: blocks}
+}
+
+// NewEphemeralResponse creates a response that is only visible to the user who invoked the command
+func NewEphemeralResponse(text string, blocks ...Block) *SectionBlocks {
+	return &SectionBlocks{ResponseType: SlackResponseTypeEphemeral, Text: text, Blocks: blocks}
 }
 
 // GetSlashCommand extracts the payload from a POST received by a slash command
 func GetSlashCommand(c *gin.Context) *SlashCommand {
 	return &SlashCommand{
-		TeamID:         c.PostForm("team_id"),
-		TeamDomain:     c.PostForm("team_domain"),
-		EnterpriseID:   c.PostForm("enterprise_id"),
-		EnterpriseName: c.PostForm("enterprise_name"),
-		ChannelID:      c.PostForm("channel_id"),
-		ChannelName:    c.PostForm("channel_name"),
-		UserID:         c.PostForm("user_id"),
-		UserName:       c.PostForm("user_name"),
-		Command:        c.PostForm("command"),
-		Txt:            c.PostForm("text"),
-		ResponseURL:    c.PostForm("response_url"),
-		TriggerID:      c.PostForm("trigger_id"),
-		Token:          c.PostForm("token"), // DEPRECATED
+		TeamID:              c.PostForm("team_id"),
+		TeamDomain:          c.PostForm("team_domain"),
+		EnterpriseID:        c.PostForm("enterprise_id"),
+		EnterpriseName:      c.PostForm("enterprise_name"),
+		IsEnterpriseInstall: c.PostForm("is_enterprise_install") == "true",
+		ChannelID:           c.PostForm("channel_id"),
+		ChannelName:         c.PostForm("channel_name"),
+		UserID:              c.PostForm("user_id"),
+		UserName:            c.PostForm("user_name"),
+		Command:             c.PostForm("command"),
+		Txt:                 c.PostForm("text"),
+		ResponseURL:         c.PostForm("response_url"),
+		TriggerID:           c.PostForm("trigger_id"),
+		Token:               c.PostForm("token"), // DEPRECATED
 	}
 }
 
+// ClientID returns the ID the installation handling the command is stored under,
+// i.e. the enterprise ID for org-wide installs and the team ID otherwise
+func (cmd *SlashCommand) ClientID() string {
+	if cmd.IsEnterpriseInstall && cmd.EnterpriseID != "" {
+		return cmd.EnterpriseID
+	}
+	return cmd.TeamID
+}
+
 // RegisterSlashCmdHandler adds a slash-cmd handler
 func RegisterSlashCmdHandler(cmd string, h SlashCommandFunc) {
 	slashCommandLookup[strings.ToLower(cmd)] = h
@@ -100,33 +124,49 @@ func RegisterDefaultSlashCmdHandler(h SlashCommandFunc) {
 	defaultSlashCommandHandler = h
 }
 
-// NewSlackCmdEror wraps an error with additional metadata
+// NewSlackCmdEror wraps an error with additional metadata. The message is only logged,
+// it is not shown to the user.
+//
+// Deprecated: use CommandError instead.
 func NewSlackCmdEror(msg string, cmd *SlashCommand, e error) error {
-	return &cmdErrorWrapper{cmd: cmd, msg: msg, err: e}
-}
-
-func (ee *cmdErrorWrapper) Error() string {
-	return fmt.Sprintf("cmd: %s, team_id: %s, channel_id: %s, user_id: %s, cmdline: %s", ee.cmd.Command, ee.cmd.TeamID, ee.cmd.ChannelID, ee.cmd.UserID, ee.cmd.Txt)
-}
-
-func (ee *cmdErrorWrapper) Unwrap() error {
-	return ee.err
+	err := CommandError(cmd, "", e)
+	if msg != "" {
+		err.Op += ": " + msg
+	}
+	return err
 }
 
+// unknownCommandHandler lists the known commands, if their help was registered
 func unknownCommandHandler(c *gin.Context, cmd *SlashCommand) (*SectionBlocks, error) {
-	return genericErrorSectionBlock(c, cmd), nil
+	resp := genericErrorSectionBlock(c, cmd)
+	resp.Blocks = append(resp.Blocks, helpOverviewBlocks()...)
+	return resp, nil
 }
 
 func genericErrorSectionBlock(c *gin.Context, cmd *SlashCommand) *SectionBlocks {
 	return &SectionBlocks{
-		Blocks: []SectionBlock{
-			{
-				Type: "section",
+		Blocks: []Block{
+			&SectionBlock{
 				Text: TextObject{
 					Type: "mrkdwn",
-					Text: fmt.Sprintf("Sorry, but I can't do this: %s %s", cmd.Command, cmd.Txt),
+					Text: fmt.Sprintf("Sorry, but I can't do this: %s %s", Escape(cmd.Command), Escape(cmd.Txt)),
 				},
 			},
 		},
 	}
 }
+
+// fallbackText returns the text of the first header or section, it is shown in notifications
+func fallbackText(blocks []Block) string {
+	for _, b := range blocks {
+		switch b := pointerTo(b).(type) {
+		case *HeaderBlock:
+			return b.Text.Text
+		case *SectionBlock:
+			if b.Text.Text != "" {
+				return b.Text.Text
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,470 @@
+package slack
+
+import (
+	"encoding/json"
+)
+
+// see https://api.slack.com/reference/block-kit/block-elements
+
+type (
+	// Button see https://api.slack.com/reference/block-kit/block-elements#button
+	// type == button
+	Button struct {
+		Type               string         `json:"type"`
+		Text               TextObject     `json:"text"` // plain_text only
+		ActionID           string         `json:"action_id,omitempty"`
+		URL                string         `json:"url,omitempty"`
+		Value              string         `json:"value,omitempty"`
+		Style              string         `json:"style,omitempty"` // primary or danger
+		Confirm            *ConfirmObject `json:"confirm,omitempty"`
+		AccessibilityLabel string         `json:"accessibility_label,omitempty"`
+	}
+
+	// OverflowMenu see https://api.slack.com/reference/block-kit/block-elements#overflow
+	// type == overflow
+	OverflowMenu struct {
+		Type     string          `json:"type"`
+		ActionID string          `json:"action_id"`
+		Options  []OptionsObject `json:"options"`
+		Confirm  *ConfirmObject  `json:"confirm,omitempty"`
+	}
+
+	// StaticSelect see https://api.slack.com/reference/block-kit/block-elements#static_select
+	// type == static_select
+	StaticSelect struct {
+		Type          string              `json:"type"`
+		ActionID      string              `json:"action_id"`
+		Placeholder   *TextObject         `json:"placeholder,omitempty"`
+		Options       []OptionsObject     `json:"options,omitempty"`
+		OptionGroups  []OptionGroupObject `json:"option_groups,omitempty"`
+		InitialOption *OptionsObject      `json:"initial_option,omitempty"`
+		Confirm       *ConfirmObject      `json:"confirm,omitempty"`
+		FocusOnLoad   bool                `json:"focus_on_load,omitempty"`
+	}
+
+	// MultiStaticSelect see https://api.slack.com/reference/block-kit/block-elements#static_multi_select
+	// type == multi_static_select
+	MultiStaticSelect struct {
+		Type             string              `json:"type"`
+		ActionID         string              `json:"action_id"`
+		Placeholder      *TextObject         `json:"placeholder,omitempty"`
+		Options          []OptionsObject     `json:"options,omitempty"`
+		OptionGroups     []OptionGroupObject `json:"option_groups,omitempty"`
+		InitialOptions   []OptionsObject     `json:"initial_options,omitempty"`
+		Confirm          *ConfirmObject      `json:"confirm,omitempty"`
+		MaxSelectedItems int                 `json:"max_selected_items,omitempty"`
+		FocusOnLoad      bool                `json:"focus_on_load,omitempty"`
+	}
+
+	// ExternalSelect see https://api.slack.com/reference/block-kit/block-elements#external_select
+	// type == external_select
+	ExternalSelect struct {
+		Type           string         `json:"type"`
+		ActionID       string         `json:"action_id"`
+		Placeholder    *TextObject    `json:"placeholder,omitempty"`
+		InitialOption  *OptionsObject `json:"initial_option,omitempty"`
+		MinQueryLength *int           `json:"min_query_length,omitempty"`
+		Confirm        *ConfirmObject `json:"confirm,omitempty"`
+		FocusOnLoad    bool           `json:"focus_on_load,omitempty"`
+	}
+
+	// MultiExternalSelect see https://api.slack.com/reference/block-kit/block-elements#external_multi_select
+	// type == multi_external_select
+	MultiExternalSelect struct {
+		Type             string          `json:"type"`
+		ActionID         string          `json:"action_id"`
+		Placeholder      *TextObject     `json:"placeholder,omitempty"`
+		InitialOptions   []OptionsObject `json:"initial_options,omitempty"`
+		MinQueryLength   *int            `json:"min_query_length,omitempty"`
+		Confirm          *ConfirmObject  `json:"confirm,omitempty"`
+		MaxSelectedItems int             `json:"max_selected_items,omitempty"`
+		FocusOnLoad      bool            `json:"focus_on_load,omitempty"`
+	}
+
+	// UsersSelect see https://api.slack.com/reference/block-kit/block-elements#users_select
+	// type == users_select
+	UsersSelect struct {
+		Type        string         `json:"type"`
+		ActionID    string         `json:"action_id"`
+		Placeholder *TextObject    `json:"placeholder,omitempty"`
+		InitialUser string         `json:"initial_user,omitempty"`
+		Confirm     *ConfirmObject `json:"confirm,omitempty"`
+		FocusOnLoad bool           `json:"focus_on_load,omitempty"`
+	}
+
+	// MultiUsersSelect see https://api.slack.com/reference/block-kit/block-elements#users_multi_select
+	// type == multi_users_select
+	MultiUsersSelect struct {
+		Type             string         `json:"type"`
+		ActionID         string         `json:"action_id"`
+		Placeholder      *TextObject    `json:"placeholder,omitempty"`
+		InitialUsers     []string       `json:"initial_users,omitempty"`
+		Confirm          *ConfirmObject `json:"confirm,omitempty"`
+		MaxSelectedItems int            `json:"max_selected_items,omitempty"`
+		FocusOnLoad      bool           `json:"focus_on_load,omitempty"`
+	}
+
+	// ConversationsSelect see https://api.slack.com/reference/block-kit/block-elements#conversations_select
+	// type == conversations_select
+	ConversationsSelect struct {
+		Type                         string              `json:"type"`
+		ActionID                     string              `json:"action_id"`
+		Placeholder                  *TextObject         `json:"placeholder,omitempty"`
+		InitialConversation          string              `json:"initial_conversation,omitempty"`
+		DefaultToCurrentConversation bool                `json:"default_to_current_conversation,omitempty"`
+		Confirm                      *ConfirmObject      `json:"confirm,omitempty"`
+		ResponseURLEnabled           bool                `json:"response_url_enabled,omitempty"`
+		Filter                       *ConversationFilter `json:"filter,omitempty"`
+		FocusOnLoad                  bool                `json:"focus_on_load,omitempty"`
+	}
+
+	// MultiConversationsSelect see https://api.slack.com/reference/block-kit/block-elements#conversation_multi_select
+	// type == multi_conversations_select
+	MultiConversationsSelect struct {
+		Type                         string              `json:"type"`
+		ActionID                     string              `json:"action_id"`
+		Placeholder                  *TextObject         `json:"placeholder,omitempty"`
+		InitialConversations         []string            `json:"initial_conversations,omitempty"`
+		DefaultToCurrentConversation bool                `json:"default_to_current_conversation,omitempty"`
+		Confirm                      *ConfirmObject      `json:"confirm,omitempty"`
+		MaxSelectedItems             int                 `json:"max_selected_items,omitempty"`
+		Filter                       *ConversationFilter `json:"filter,omitempty"`
+		FocusOnLoad                  bool                `json:"focus_on_load,omitempty"`
+	}
+
+	// ChannelsSelect see https://api.slack.com/reference/block-kit/block-elements#channels_select
+	// type == channels_select
+	ChannelsSelect struct {
+		Type               string         `json:"type"`
+		ActionID           string         `json:"action_id"`
+		Placeholder        *TextObject    `json:"placeholder,omitempty"`
+		InitialChannel     string         `json:"initial_channel,omitempty"`
+		Confirm            *ConfirmObject `json:"confirm,omitempty"`
+		ResponseURLEnabled bool           `json:"response_url_enabled,omitempty"`
+		FocusOnLoad        bool           `json:"focus_on_load,omitempty"`
+	}
+
+	// MultiChannelsSelect see https://api.slack.com/reference/block-kit/block-elements#channel_multi_select
+	// type == multi_channels_select
+	MultiChannelsSelect struct {
+		Type             string         `json:"type"`
+		ActionID         string         `json:"action_id"`
+		Placeholder      *TextObject    `json:"placeholder,omitempty"`
+		InitialChannels  []string       `json:"initial_channels,omitempty"`
+		Confirm          *ConfirmObject `json:"confirm,omitempty"`
+		MaxSelectedItems int            `json:"max_selected_items,omitempty"`
+		FocusOnLoad      bool           `json:"focus_on_load,omitempty"`
+	}
+
+	// DatePicker see https://api.slack.com/reference/block-kit/block-elements#datepicker
+	// type == datepicker
+	DatePicker struct {
+		Type        string         `json:"type"`
+		ActionID    string         `json:"action_id"`
+		Placeholder *TextObject    `json:"placeholder,omitempty"`
+		InitialDate string         `json:"initial_date,omitempty"` // YYYY-MM-DD
+		Confirm     *ConfirmObject `json:"confirm,omitempty"`
+		FocusOnLoad bool           `json:"focus_on_load,omitempty"`
+	}
+
+	// TimePicker see https://api.slack.com/reference/block-kit/block-elements#timepicker
+	// type == timepicker
+	TimePicker struct {
+		Type        string         `json:"type"`
+		ActionID    string         `json:"action_id"`
+		Placeholder *TextObject    `json:"placeholder,omitempty"`
+		InitialTime string         `json:"initial_time,omitempty"` // HH:mm
+		Confirm     *ConfirmObject `json:"confirm,omitempty"`
+		FocusOnLoad bool           `json:"focus_on_load,omitempty"`
+		Timezone    string         `json:"timezone,omitempty"`
+	}
+
+	// DateTimePicker see https://api.slack.com/reference/block-kit/block-elements#datetimepicker
+	// type == datetimepicker
+	DateTimePicker struct {
+		Type            string         `json:"type"`
+		ActionID        string         `json:"action_id"`
+		InitialDateTime int64          `json:"initial_date_time,omitempty"` // UNIX timestamp in seconds
+		Confirm         *ConfirmObject `json:"confirm,omitempty"`
+		FocusOnLoad     bool           `json:"focus_on_load,omitempty"`
+	}
+
+	// PlainTextInput see https://api.slack.com/reference/block-kit/block-elements#input
+	// type == plain_text_input
+	PlainTextInput struct {
+		Type                 string                `json:"type"`
+		ActionID             string                `json:"action_id"`
+		Placeholder          *TextObject           `json:"placeholder,omitempty"`
+		InitialValue         string                `json:"initial_value,omitempty"`
+		Multiline            bool                  `json:"multiline,omitempty"`
+		MinLength            int                   `json:"min_length,omitempty"`
+		MaxLength            int                   `json:"max_length,omitempty"`
+		DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+		FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+	}
+
+	// EmailInput see https://api.slack.com/reference/block-kit/block-elements#email
+	// type == email_text_input
+	EmailInput struct {
+		Type                 string                `json:"type"`
+		ActionID             string                `json:"action_id"`
+		Placeholder          *TextObject           `json:"placeholder,omitempty"`
+		InitialValue         string                `json:"initial_value,omitempty"`
+		DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+		FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+	}
+
+	// URLInput see https://api.slack.com/reference/block-kit/block-elements#url
+	// type == url_text_input
+	URLInput struct {
+		Type                 string                `json:"type"`
+		ActionID             string                `json:"action_id"`
+		Placeholder          *TextObject           `json:"placeholder,omitempty"`
+		InitialValue         string                `json:"initial_value,omitempty"`
+		DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+		FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+	}
+
+	// NumberInput see https://api.slack.com/reference/block-kit/block-elements#number
+	// type == number_input
+	NumberInput struct {
+		Type                 string                `json:"type"`
+		ActionID             string                `json:"action_id"`
+		IsDecimalAllowed     bool                  `json:"is_decimal_allowed"`
+		Placeholder          *TextObject           `json:"placeholder,omitempty"`
+		InitialValue         string                `json:"initial_value,omitempty"`
+		MinValue             string                `json:"min_value,omitempty"`
+		MaxValue             string                `json:"max_value,omitempty"`
+		DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+		FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+	}
+
+	// RichTextInput see https://api.slack.com/reference/block-kit/block-elements#rich_text_input
+	// type == rich_text_input
+	RichTextInput struct {
+		Type                 string                `json:"type"`
+		ActionID             string                `json:"action_id"`
+		Placeholder          *TextObject           `json:"placeholder,omitempty"`
+		InitialValue         *RichTextBlock        `json:"initial_value,omitempty"`
+		DispatchActionConfig *DispatchActionConfig `json:"dispatch_action_config,omitempty"`
+		FocusOnLoad          bool                  `json:"focus_on_load,omitempty"`
+	}
+
+	// OptionGroupObject see https://api.slack.com/reference/block-kit/composition-objects#option_group
+	OptionGroupObject struct {
+		Label   TextObject      `json:"label"` // plain_text only
+		Options []OptionsObject `json:"options"`
+	}
+
+	// ConversationFilter see https://api.slack.com/reference/block-kit/composition-objects#filter_conversations
+	ConversationFilter struct {
+		Include                       []string `json:"include,omitempty"` // im, mpim, private, public
+		ExcludeExternalSharedChannels bool     `json:"exclude_external_shared_channels,omitempty"`
+		ExcludeBotUsers               bool     `json:"exclude_bot_users,omitempty"`
+	}
+
+	// DispatchActionConfig see https://api.slack.com/reference/block-kit/composition-objects#dispatch_action_config
+	DispatchActionConfig struct {
+		TriggerActionsOn []string `json:"trigger_actions_on,omitempty"` // on_enter_pressed, on_character_entered
+	}
+)
+
+// ElementType implements BlockElement
+func (e Button) ElementType() string { return "button" }
+
+// ElementType implements BlockElement
+func (e OverflowMenu) ElementType() string { return "overflow" }
+
+// ElementType implements BlockElement
+func (e StaticSelect) ElementType() string { return "static_select" }
+
+// ElementType implements BlockElement
+func (e MultiStaticSelect) ElementType() string { return "multi_static_select" }
+
+// ElementType implements BlockElement
+func (e ExternalSelect) ElementType() string { return "external_select" }
+
+// ElementType implements BlockElement
+func (e MultiExternalSelect) ElementType() string { return "multi_external_select" }
+
+// ElementType implements BlockElement
+func (e UsersSelect) ElementType() string { return "users_select" }
+
+// ElementType implements BlockElement
+func (e MultiUsersSelect) ElementType() string { return "multi_users_select" }
+
+// ElementType implements BlockElement
+func (e ConversationsSelect) ElementType() string { return "conversations_select" }
+
+// ElementType implements BlockElement
+func (e MultiConversationsSelect) ElementType() string { return "multi_conversations_select" }
+
+// ElementType implements BlockElement
+func (e ChannelsSelect) ElementType() string { return "channels_select" }
+
+// ElementType implements BlockElement
+func (e MultiChannelsSelect) ElementType() string { return "multi_channels_select" }
+
+// ElementType implements BlockElement
+func (e DatePicker) ElementType() string { return "datepicker" }
+
+// ElementType implements BlockElement
+func (e TimePicker) ElementType() string { return "timepicker" }
+
+// ElementType implements BlockElement
+func (e DateTimePicker) ElementType() string { return "datetimepicker" }
+
+// ElementType implements BlockElement
+func (e PlainTextInput) ElementType() string { return "plain_text_input" }
+
+// ElementType implements BlockElement
+func (e EmailInput) ElementType() string { return "email_text_input" }
+
+// ElementType implements BlockElement
+func (e URLInput) ElementType() string { return "url_text_input" }
+
+// ElementType implements BlockElement
+func (e NumberInput) ElementType() string { return "number_input" }
+
+// ElementType implements BlockElement
+func (e RichTextInput) ElementType() string { return "rich_text_input" }
+
+// MarshalJSON sets the element type
+func (e Button) MarshalJSON() ([]byte, error) {
+	type alias Button
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e OverflowMenu) MarshalJSON() ([]byte, error) {
+	type alias OverflowMenu
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e StaticSelect) MarshalJSON() ([]byte, error) {
+	type alias StaticSelect
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e MultiStaticSelect) MarshalJSON() ([]byte, error) {
+	type alias MultiStaticSelect
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e ExternalSelect) MarshalJSON() ([]byte, error) {
+	type alias ExternalSelect
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e MultiExternalSelect) MarshalJSON() ([]byte, error) {
+	type alias MultiExternalSelect
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e UsersSelect) MarshalJSON() ([]byte, error) {
+	type alias UsersSelect
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e MultiUsersSelect) MarshalJSON() ([]byte, error) {
+	type alias MultiUsersSelect
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e ConversationsSelect) MarshalJSON() ([]byte, error) {
+	type alias ConversationsSelect
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e MultiConversationsSelect) MarshalJSON() ([]byte, error) {
+	type alias MultiConversationsSelect
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e ChannelsSelect) MarshalJSON() ([]byte, error) {
+	type alias ChannelsSelect
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e MultiChannelsSelect) MarshalJSON() ([]byte, error) {
+	type alias MultiChannelsSelect
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e DatePicker) MarshalJSON() ([]byte, error) {
+	type alias DatePicker
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e TimePicker) MarshalJSON() ([]byte, error) {
+	type alias TimePicker
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e DateTimePicker) MarshalJSON() ([]byte, error) {
+	type alias DateTimePicker
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e PlainTextInput) MarshalJSON() ([]byte, error) {
+	type alias PlainTextInput
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e EmailInput) MarshalJSON() ([]byte, error) {
+	type alias EmailInput
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e URLInput) MarshalJSON() ([]byte, error) {
+	type alias URLInput
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e NumberInput) MarshalJSON() ([]byte, error) {
+	type alias NumberInput
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e RichTextInput) MarshalJSON() ([]byte, error) {
+	type alias RichTextInput
+	e.Type = e.ElementType()
+	return json.Marshal(alias(e))
+}
@@ -0,0 +1,368 @@
+package slack
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+
+	"github.com/txsvc/commons/pkg/util"
+	"github.com/txsvc/platform/pkg/platform"
+	"github.com/txsvc/service/pkg/auth"
+)
+
+// Tokens are stored using envelope encryption: every token is encrypted with its own random
+// data key, the data key is encrypted with a key encryption key (KEK) from the KeyProvider.
+// The stored value has the format enc:v1:<key id>:<encrypted data key>:<encrypted token>.
+// The client ID of the installation is authenticated as additional data, so an encrypted token
+// can not be copied to the record of another installation.
+//
+// If SLACK_TOKEN_KEYRING or SLACK_TOKEN_ENCRYPTION_KEY is set but the key can not be loaded,
+// EncryptToken and DecryptToken fail instead of falling back to plaintext.
+//
+// Once a KeyProvider is configured, auth.GetToken and auth.GetAuthorization of
+// github.com/txsvc/service return the encrypted value for Slack installations. Use GetToken
+// of this package, or DecryptToken, to get the plaintext token.
+
+const (
+	// SlackTokenEncryptionKey is a base64 encoded AES key (16, 24 or 32 bytes) used to encrypt stored tokens
+	SlackTokenEncryptionKey string = "SLACK_TOKEN_ENCRYPTION_KEY"
+	// SlackTokenEncryptionKeyID is the ID of SLACK_TOKEN_ENCRYPTION_KEY, defaults to 'default'
+	SlackTokenEncryptionKeyID string = "SLACK_TOKEN_ENCRYPTION_KEY_ID"
+	// SlackTokenKeyring is the path of a keyring file, see NewFileKeyProvider
+	SlackTokenKeyring string = "SLACK_TOKEN_KEYRING"
+
+	encryptedTokenPrefix = "enc:v1:"
+)
+
+type (
+	// KeyProvider supplies the key encryption keys used to protect stored tokens
+	KeyProvider interface {
+		// CurrentKey returns the key used to encrypt new tokens, and its ID
+		CurrentKey() (string, []byte, error)
+		// Key returns the key with the given ID, used to decrypt tokens
+		Key(id string) ([]byte, error)
+	}
+
+	// StaticKeyProvider is a KeyProvider with a single key
+	StaticKeyProvider struct {
+		ID     string
+		Secret []byte
+	}
+
+	// FileKeyProvider is a KeyProvider backed by a keyring file.
+	// Rotate keys by adding a new key and pointing Current to it, older keys remain for decryption.
+	FileKeyProvider struct {
+		Current string            `json:"current"`
+		Keys    map[string]string `json:"keys"` // key ID -> base64 encoded key
+	}
+)
+
+// the key provider, nil if tokens are stored in plaintext
+var keyProvider KeyProvider
+var keyProviderErr error // the configured key could not be loaded
+var keyProviderOnce sync.Once
+
+// SetKeyProvider configures the KeyProvider used to encrypt stored tokens.
+// If none is set, SLACK_TOKEN_KEYRING or SLACK_TOKEN_ENCRYPTION_KEY are used, if present.
+func SetKeyProvider(p KeyProvider) {
+	keyProviderOnce.Do(func() {})
+	keyProvider = p
+	keyProviderErr = nil
+}
+
+// CheckKeyProvider returns an error if token encryption is configured but the key can not be loaded.
+// Call it at startup to fail early instead of on the first installation.
+func CheckKeyProvider() error {
+	_, err := getKeyProvider()
+	return err
+}
+
+// NewStaticKeyProvider creates a KeyProvider from SLACK_TOKEN_ENCRYPTION_KEY and SLACK_TOKEN_ENCRYPTION_KEY_ID
+func NewStaticKeyProvider() (*StaticKeyProvider, error) {
+	secret, err := base64.StdEncoding.DecodeString(os.Getenv(SlackTokenEncryptionKey))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKeySize(secret); err != nil {
+		return nil, err
+	}
+	return &StaticKeyProvider{
+		ID:     util.ValueWithDefault(os.Getenv(SlackTokenEncryptionKeyID), "default"),
+		Secret: secret,
+	}, nil
+}
+
+// CurrentKey returns the key and its ID
+func (p *StaticKeyProvider) CurrentKey() (string, []byte, error) {
+	return p.ID, p.Secret, nil
+}
+
+// Key returns the key if the ID matches
+func (p *StaticKeyProvider) Key(id string) ([]byte, error) {
+	if id != p.ID {
+		return nil, fmt.Errorf("unknown key '%s'", id)
+	}
+	return p.Secret, nil
+}
+
+// NewFileKeyProvider reads a keyring file like {"current": "k2", "keys": {"k1": "<base64>", "k2": "<base64>"}}
+func NewFileKeyProvider(path string) (*FileKeyProvider, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var p FileKeyProvider
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, err
+	}
+	if _, ok := p.Keys[p.Current]; !ok {
+		return nil, fmt.Errorf("unknown current key '%s'", p.Current)
+	}
+	for id := range p.Keys {
+		key, err := p.Key(id)
+		if err != nil {
+			return nil, fmt.Errorf("key '%s': %v", id, err)
+		}
+		if err := checkKeySize(key); err != nil {
+			return nil, fmt.Errorf("key '%s': %v", id, err)
+		}
+	}
+	return &p, nil
+}
+
+// CurrentKey returns the key marked as current, and its ID
+func (p *FileKeyProvider) CurrentKey() (string, []byte, error) {
+	key, err := p.Key(p.Current)
+	return p.Current, key, err
+}
+
+// Key returns the key with the given ID
+func (p *FileKeyProvider) Key(id string) ([]byte, error) {
+	k, ok := p.Keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown key '%s'", id)
+	}
+	return base64.StdEncoding.DecodeString(k)
+}
+
+// EncryptToken encrypts the token of an installation with the current key. The clientID is
+// the team or enterprise ID of the installation. Tokens are returned unchanged if no KeyProvider is configured.
+func EncryptToken(clientID, token string) (string, error) {
+	p, err := getKeyProvider()
+	if err != nil {
+		return "", err
+	}
+	if p == nil || token == "" {
+		return token, nil
+	}
+
+	kid, kek, err := p.CurrentKey()
+	if err != nil {
+		return "", err
+	}
+	if strings.Contains(kid, ":") {
+		return "", fmt.Errorf("invalid key id '%s'", kid)
+	}
+
+	dek := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return "", err
+	}
+
+	wrapped, err := seal(kek, dek, nil)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(dek, []byte(token), tokenAdditionalData(clientID))
+	if err != nil {
+		return "", err
+	}
+
+	return encryptedTokenPrefix + kid + ":" + base64.RawURLEncoding.EncodeToString(wrapped) + ":" + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptToken decrypts a token created by EncryptToken for the same clientID. Plaintext tokens are returned unchanged.
+func DecryptToken(clientID, token string) (string, error) {
+	if !IsEncryptedToken(token) {
+		return token, nil
+	}
+
+	p, err := getKeyProvider()
+	if err != nil {
+		return "", err
+	}
+	if p == nil {
+		return "", fmt.Errorf("encrypted token but no key provider")
+	}
+
+	parts := strings.Split(strings.TrimPrefix(token, encryptedTokenPrefix), ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed encrypted token")
+	}
+
+	kek, err := p.Key(parts[0])
+	if err != nil {
+		return "", err
+	}
+	wrapped, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", err
+	}
+
+	dek, err := open(kek, wrapped, nil)
+	if err != nil {
+		return "", err
+	}
+	plain, err := open(dek, sealed, tokenAdditionalData(clientID))
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// IsEncryptedToken returns true if the token was encrypted by EncryptToken
+func IsEncryptedToken(token string) bool {
+	return strings.HasPrefix(token, encryptedTokenPrefix)
+}
+
+// getInstallationToken returns the decrypted token of an installation. Plaintext tokens
+// and tokens encrypted with a retired key are re-encrypted with the current key.
+func getInstallationToken(ctx context.Context, clientID string) (string, error) {
+	token, err := auth.GetToken(ctx, clientID, auth.AuthTypeSlack)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", fmt.Errorf("installation '%s' was revoked", clientID)
+	}
+
+	if needsEncryption(token) {
+		if err := migrateToken(ctx, clientID, token); err != nil {
+			platform.ReportError(err) // the token is still usable
+		}
+	}
+
+	return DecryptToken(clientID, token)
+}
+
+// migrateToken encrypts the stored token of an installation with the current key
+func migrateToken(ctx context.Context, clientID, token string) error {
+	a, err := findAuthorization(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	if a.Token != token {
+		return nil // e.g. the token was supplied by the environment
+	}
+
+	plain, err := DecryptToken(clientID, token)
+	if err != nil {
+		return err
+	}
+	a.Token, err = EncryptToken(clientID, plain)
+	if err != nil {
+		return err
+	}
+	a.Updated = util.Timestamp()
+
+	return auth.CreateAuthorization(ctx, a)
+}
+
+// needsEncryption returns true if a stored token is not encrypted with the current key
+func needsEncryption(token string) bool {
+	p, err := getKeyProvider()
+	if p == nil || err != nil {
+		return false
+	}
+	if !IsEncryptedToken(token) {
+		return true
+	}
+	kid, _, err := p.CurrentKey()
+	if err != nil {
+		return false
+	}
+	return !strings.HasPrefix(token, encryptedTokenPrefix+kid+":")
+}
+
+// getKeyProvider returns the configured KeyProvider, or an error if the key of
+// SLACK_TOKEN_KEYRING or SLACK_TOKEN_ENCRYPTION_KEY could not be loaded
+func getKeyProvider() (KeyProvider, error) {
+	keyProviderOnce.Do(func() {
+		if path := os.Getenv(SlackTokenKeyring); path != "" {
+			p, err := NewFileKeyProvider(path)
+			if err != nil {
+				keyProviderErr = fmt.Errorf("token encryption: %v", err)
+				return
+			}
+			keyProvider = p
+		} else if os.Getenv(SlackTokenEncryptionKey) != "" {
+			p, err := NewStaticKeyProvider()
+			if err != nil {
+				keyProviderErr = fmt.Errorf("token encryption: %v", err)
+				return
+			}
+			keyProvider = p
+		}
+	})
+	return keyProvider, keyProviderErr
+}
+
+// checkKeySize verifies that a key can be used with AES
+func checkKeySize(key []byte) error {
+	switch len(key) {
+	case 16, 24, 32:
+		return nil
+	}
+	return fmt.Errorf("invalid key size %d, want 16, 24 or 32 bytes", len(key))
+}
+
+// tokenAdditionalData binds an encrypted token to its installation
+func tokenAdditionalData(clientID string) []byte {
+	return []byte("slack:" + clientID)
+}
+
+func seal(key, plain, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plain, additionalData), nil
+}
+
+func open(key, sealed, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("malformed ciphertext")
+	}
+	return gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
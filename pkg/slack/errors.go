@@ -0,0 +1,247 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/txsvc/commons/pkg/util"
+	"github.com/txsvc/platform/pkg/platform"
+)
+
+type (
+	// UserError is an error with a message that is safe to show to the user. The wrapped
+	// error holds the internal details, it is logged but never shown.
+	UserError struct {
+		Message string
+		Err     error
+	}
+
+	// HandlerError is the error of a command, action or event handler. It carries where the
+	// error happened, the cause, an optional message for the user and fields for structured logging.
+	HandlerError struct {
+		Op        string // e.g. "command /deploy", "action approve" or "event app_uninstalled"
+		TeamID    string
+		ChannelID string
+		UserID    string
+		Message   string // safe to show to the user, optional
+		Err       error
+		Fields    map[string]interface{}
+	}
+
+	// ErrorRenderer renders the message shown to the user when a handler fails.
+	// The correlation ID is logged with the error and should be shown to the user.
+	ErrorRenderer func(c *gin.Context, i *Interaction, err error, correlationID string) *SectionBlocks
+
+	// viewResponse answers a view submission, e.g. by pushing a view with a message on top of the modal
+	viewResponse struct {
+		ResponseAction string       `json:"response_action"`
+		View           *ViewElement `json:"view"`
+	}
+)
+
+var errorRenderer ErrorRenderer = DefaultErrorRenderer
+
+// NewUserError creates an error with a message for the user
+func NewUserError(msg string, err error) error {
+	return &UserError{Message: msg, Err: err}
+}
+
+func (e *UserError) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+// Unwrap returns the internal error
+func (e *UserError) Unwrap() error {
+	return e.Err
+}
+
+// UserMessage returns the message for the user
+func (e *UserError) UserMessage() string {
+	return e.Message
+}
+
+// UserMessage returns the user-facing message of an error, if any error in its chain has one
+func UserMessage(err error) string {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if um, ok := err.(interface{ UserMessage() string }); ok && um.UserMessage() != "" {
+			return um.UserMessage()
+		}
+	}
+	return ""
+}
+
+// SetErrorRenderer replaces the renderer of error messages
+func SetErrorRenderer(r ErrorRenderer) {
+	errorRenderer = r
+}
+
+// DefaultErrorRenderer shows the user-facing message of the error, or a generic message,
+// followed by the correlation ID
+func DefaultErrorRenderer(c *gin.Context, i *Interaction, err error, correlationID string) *SectionBlocks {
+	text := Escape(UserMessage(err))
+	if text == "" {
+		text = "Sorry, something went wrong"
+		if i.Type == InteractionSlashCommand {
+			text += " while running " + Code(Escape(i.Name))
+		}
+		text += "."
+	}
+	text = ":warning: " + text
+
+	return NewEphemeralResponse(text,
+		&SectionBlock{Text: TextObject{Type: "mrkdwn", Text: text}},
+		&ContextBlock{Elements: []ContextElement{
+			&TextObject{Type: "mrkdwn", Text: "Reference: " + Code(correlationID)},
+		}},
+	)
+}
+
+// handleError reports the error with a new correlation ID and renders the message for the user
+func handleError(c *gin.Context, i *Interaction, err error) *SectionBlocks {
+	id, e := util.ShortUUID()
+	if e != nil {
+		id = fmt.Sprintf("%d", util.TimestampNano())
+	}
+
+	platform.ReportError(fmt.Errorf("correlation_id=%s %s '%s': %w", id, i.Type, i.Name, err))
+	return errorRenderer(c, i, err, id)
+}
+
+// messageView wraps a message into a modal
+func messageView(title string, msg *SectionBlocks) *ViewElement {
+	return &ViewElement{
+		Type:   "modal",
+		Title:  DefaultViewElement{Type: "plain_text", Text: title},
+		Close:  &DefaultViewElement{Type: "plain_text", Text: "Close"},
+		Blocks: msg.Blocks,
+	}
+}
+
+// NewHandlerError creates an error for an operation
+func NewHandlerError(op string, err error) *HandlerError {
+	return &HandlerError{Op: op, Err: err}
+}
+
+// CommandError creates an error for a slash command. The message is shown to the user.
+func CommandError(cmd *SlashCommand, msg string, err error) *HandlerError {
+	return &HandlerError{
+		Op:        "command " + cmd.Command,
+		TeamID:    cmd.TeamID,
+		ChannelID: cmd.ChannelID,
+		UserID:    cmd.UserID,
+		Message:   msg,
+		Err:       err,
+		Fields:    map[string]interface{}{"cmdline": cmd.Txt},
+	}
+}
+
+// InteractionError creates an error for a slash command, action or view submission.
+// The message is shown to the user.
+func InteractionError(i *Interaction, msg string, err error) *HandlerError {
+	op := i.Name
+	switch i.Type {
+	case InteractionSlashCommand:
+		op = "command " + i.Name
+	case InteractionMessageAction, InteractionViewSubmission:
+		op = "action " + i.Name
+	}
+	return &HandlerError{
+		Op:        op,
+		TeamID:    i.TeamID(),
+		ChannelID: i.ChannelID(),
+		UserID:    i.UserID(),
+		Message:   msg,
+		Err:       err,
+	}
+}
+
+// EventError creates an error for an event
+func EventError(ec *EventCallback, err error) *HandlerError {
+	return &HandlerError{
+		Op:     "event " + ec.EventType(),
+		TeamID: ec.TeamID,
+		Err:    err,
+		Fields: map[string]interface{}{"event_id": ec.EventID},
+	}
+}
+
+// With adds a field for structured logging
+func (e *HandlerError) With(key string, value interface{}) *HandlerError {
+	if e.Fields == nil {
+		e.Fields = make(map[string]interface{})
+	}
+	e.Fields[key] = value
+	return e
+}
+
+func (e *HandlerError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Op)
+	if e.Message != "" {
+		b.WriteString(": " + e.Message)
+	}
+	if e.Err != nil {
+		b.WriteString(": " + e.Err.Error())
+	}
+
+	var ids []string
+	for _, kv := range [][2]string{{"team_id", e.TeamID}, {"channel_id", e.ChannelID}, {"user_id", e.UserID}} {
+		if kv[1] != "" {
+			ids = append(ids, kv[0]+"="+kv[1])
+		}
+	}
+	if len(ids) > 0 {
+		b.WriteString(" (" + strings.Join(ids, ", ") + ")")
+	}
+	return b.String()
+}
+
+// Unwrap returns the cause
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// UserMessage returns the message for the user
+func (e *HandlerError) UserMessage() string {
+	return e.Message
+}
+
+// LogFields returns all details of the error for structured loggers
+func (e *HandlerError) LogFields() map[string]interface{} {
+	fields := make(map[string]interface{}, len(e.Fields)+6)
+	for k, v := range e.Fields {
+		fields[k] = v
+	}
+	fields["op"] = e.Op
+	for k, v := range map[string]string{"team_id": e.TeamID, "channel_id": e.ChannelID, "user_id": e.UserID, "message": e.Message} {
+		if v != "" {
+			fields[k] = v
+		}
+	}
+	if e.Err != nil {
+		fields["error"] = e.Err.Error()
+	}
+	return fields
+}
+
+// String returns the error with all its fields, sorted by key
+func (e *HandlerError) String() string {
+	fields := e.LogFields()
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%q", k, fmt.Sprint(fields[k]))
+	}
+	return strings.Join(parts, " ")
+}
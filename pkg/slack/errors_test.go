@@ -0,0 +1,83 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUserMessage(t *testing.T) {
+	internal := errors.New("datastore: connection refused")
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, ""},
+		{"internal error", internal, ""},
+		{"user error", NewUserError("Could not save", internal), "Could not save"},
+		{"wrapped user error", fmt.Errorf("save: %w", NewUserError("Could not save", internal)), "Could not save"},
+		{"handler error", NewHandlerError("command /x", internal), ""},
+		{"handler error with message", &HandlerError{Op: "command /x", Message: "Try again", Err: internal}, "Try again"},
+		{"handler error wrapping a user error", NewHandlerError("command /x", NewUserError("Unknown service", internal)), "Unknown service"},
+		{"outer message wins", &HandlerError{Message: "Outer", Err: NewUserError("Inner", nil)}, "Outer"},
+		{"user error wrapped twice", fmt.Errorf("a: %w", fmt.Errorf("b: %w", NewUserError("Deep", nil))), "Deep"},
+		{"not wrapped", fmt.Errorf("lost: %v", NewUserError("Hidden", nil)), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UserMessage(tt.err); got != tt.want {
+				t.Errorf("UserMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultErrorRenderer(t *testing.T) {
+	cmd := &Interaction{Type: InteractionSlashCommand, Name: "/deploy"}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"generic", errors.New("secret details"), ":warning: Sorry, something went wrong while running `/deploy`."},
+		{"user message", NewUserError("Service <api> not found", errors.New("secret details")), ":warning: Service &lt;api&gt; not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := DefaultErrorRenderer(nil, cmd, tt.err, "abc123")
+			if msg.ResponseType != SlackResponseTypeEphemeral || msg.Text != tt.want {
+				t.Errorf("DefaultErrorRenderer() = %q (%s), want %q", msg.Text, msg.ResponseType, tt.want)
+			}
+			ref := msg.Blocks[1].(*ContextBlock).Elements[0].(*TextObject).Text
+			if !strings.Contains(ref, "abc123") {
+				t.Errorf("reference = %q, want the correlation ID", ref)
+			}
+			if strings.Contains(msg.Text, "secret") {
+				t.Error("internal error shown to the user")
+			}
+		})
+	}
+}
+
+func TestNewSlackCmdErorIsNotShown(t *testing.T) {
+	cmd := &SlashCommand{Command: "/deploy", TeamID: "T1", UserID: "U1", Txt: "api"}
+	err := NewSlackCmdEror("datastore lookup of api failed", cmd, errors.New("timeout"))
+
+	if msg := UserMessage(err); msg != "" {
+		t.Errorf("UserMessage() = %q, want no user-facing message", msg)
+	}
+	if !strings.Contains(err.Error(), "datastore lookup of api failed") {
+		t.Errorf("Error() = %q, want the legacy message to be logged", err.Error())
+	}
+
+	resp := DefaultErrorRenderer(nil, &Interaction{Type: InteractionSlashCommand, Name: "/deploy"}, err, "abc123")
+	if strings.Contains(resp.Text, "datastore") {
+		t.Errorf("rendered %q, the legacy message must not be shown", resp.Text)
+	}
+}
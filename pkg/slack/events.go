@@ -0,0 +1,173 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/appengine"
+
+	"github.com/txsvc/platform/pkg/platform"
+)
+
+// see https://api.slack.com/apis/connections/events-api
+
+type (
+	// EventHandlerFunc handles an event received from the Events API
+	EventHandlerFunc func(*gin.Context, *EventCallback) error
+
+	// EventCallback is the outer payload of an event sent by the Events API
+	// type == event_callback, or url_verification during the endpoint setup
+	EventCallback struct {
+		Type           string               `json:"type,omitempty"`
+		Token          string               `json:"token,omitempty"`
+		Challenge      string               `json:"challenge,omitempty"`
+		TeamID         string               `json:"team_id,omitempty"`
+		EnterpriseID   string               `json:"enterprise_id,omitempty"`
+		APIAppID       string               `json:"api_app_id,omitempty"`
+		EventID        string               `json:"event_id,omitempty"`
+		EventTime      int64                `json:"event_time,omitempty"`
+		Authorizations []EventAuthorization `json:"authorizations,omitempty"`
+		Event          json.RawMessage      `json:"event,omitempty"`
+	}
+
+	// EventAuthorization identifies an installation the event is visible to
+	EventAuthorization struct {
+		EnterpriseID        string `json:"enterprise_id,omitempty"`
+		TeamID              string `json:"team_id,omitempty"`
+		UserID              string `json:"user_id,omitempty"`
+		IsBot               bool   `json:"is_bot,omitempty"`
+		IsEnterpriseInstall bool   `json:"is_enterprise_install,omitempty"`
+	}
+
+	// EventPeek is used to determin the type of the inner event
+	EventPeek struct {
+		Type string `json:"type,omitempty"`
+	}
+
+	// TokensRevokedEvent see https://api.slack.com/events/tokens_revoked
+	// type == tokens_revoked
+	TokensRevokedEvent struct {
+		Type   string `json:"type,omitempty"`
+		Tokens struct {
+			OAuth []string `json:"oauth,omitempty"`
+			Bot   []string `json:"bot,omitempty"`
+		} `json:"tokens"`
+	}
+)
+
+// event callback lookup
+var eventLookup map[string]EventHandlerFunc
+
+// EventsEndpoint receives callbacks from the Slack Events API. Requests must be signed
+// with SLACK_SIGNING_SECRET, others are rejected with 401.
+func EventsEndpoint(c *gin.Context) {
+	var ec EventCallback
+
+	if err := VerifyRequest(c); err != nil {
+		platform.ReportError(fmt.Errorf("events: %w", err))
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	err := c.BindJSON(&ec)
+	if err != nil {
+		platform.ReportError(err)
+		return
+	}
+
+	// the deprecated verification token is checked in addition, if configured
+	if token := os.Getenv(SlackVerificationToken); token != "" && !hmac.Equal([]byte(ec.Token), []byte(token)) {
+		platform.ReportError(fmt.Errorf("events: invalid verification token"))
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	if ec.Type == "url_verification" {
+		c.JSON(http.StatusOK, gin.H{"challenge": ec.Challenge})
+		return
+	}
+
+	if ec.Type != "event_callback" {
+		platform.ReportError(fmt.Errorf("Unknown event request: '%s'", ec.Type))
+		c.Status(http.StatusOK)
+		return
+	}
+
+	eventType := ec.EventType()
+	handler := eventLookup[strings.ToLower(eventType)]
+	if handler == nil {
+		platform.ReportError(fmt.Errorf("No handler for event '%s'", eventType))
+		c.Status(http.StatusOK)
+		return
+	}
+
+	err = handler(c, &ec)
+	if err != nil {
+		platform.ReportError(EventError(&ec, err))
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "msg": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// RegisterEventHandler adds an event handler
+func RegisterEventHandler(event string, h EventHandlerFunc) {
+	eventLookup[strings.ToLower(event)] = h
+}
+
+// EventType returns the type of the inner event
+func (ec *EventCallback) EventType() string {
+	var peek EventPeek
+	if err := json.Unmarshal(ec.Event, &peek); err != nil {
+		return ""
+	}
+	return peek.Type
+}
+
+// ClientID returns the ID the installation receiving the event is stored under,
+// i.e. the enterprise ID for org-wide installs and the team ID otherwise
+func (ec *EventCallback) ClientID() string {
+	for _, a := range ec.Authorizations {
+		if a.IsEnterpriseInstall && a.EnterpriseID != "" {
+			return a.EnterpriseID
+		}
+	}
+	if ec.TeamID == "" {
+		return ec.EnterpriseID
+	}
+	return ec.TeamID
+}
+
+// appUninstalledHandler revokes the installation once the app was removed from a workspace
+func appUninstalledHandler(c *gin.Context, ec *EventCallback) error {
+	return RevokeAuthorization(appengine.NewContext(c.Request), ec.ClientID())
+}
+
+// tokensRevokedHandler revokes the installation if its bot token was revoked
+func tokensRevokedHandler(c *gin.Context, ec *EventCallback) error {
+	var e TokensRevokedEvent
+	if err := json.Unmarshal(ec.Event, &e); err != nil {
+		return err
+	}
+
+	ctx := appengine.NewContext(c.Request)
+	clientID := ec.ClientID()
+
+	a, err := findAuthorization(ctx, clientID)
+	if err != nil || a == nil {
+		return nil // nothing to revoke
+	}
+
+	for _, bot := range e.Tokens.Bot {
+		if bot == a.UserID {
+			return RevokeAuthorization(ctx, clientID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package slack
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type (
+	// CommandHelp describes a slash command. Commands created with a CommandRouter describe
+	// themselves, other commands can register their help with RegisterSlashCmdHelp.
+	CommandHelp struct {
+		Command     string
+		Description string
+		Usage       string
+		Examples    []string
+		Subcommands []SubcommandHelp
+	}
+
+	// SubcommandHelp describes a subcommand
+	SubcommandHelp struct {
+		Name        string
+		Description string
+		Usage       string
+		Arguments   []ArgumentHelp
+		Examples    []string
+	}
+
+	// ArgumentHelp describes an argument or flag
+	ArgumentHelp struct {
+		Name        string
+		Description string
+	}
+)
+
+// help of the commands, resolved when it is shown so that routers can be extended after Register
+var slashCommandHelp map[string]func() *CommandHelp
+
+// RegisterSlashCmdHelp adds the help of a slash command. It is shown by the default handler.
+func RegisterSlashCmdHelp(h *CommandHelp) {
+	registerSlashCmdHelpFunc(h.Command, func() *CommandHelp { return h })
+}
+
+func registerSlashCmdHelpFunc(command string, f func() *CommandHelp) {
+	slashCommandHelp[strings.ToLower(command)] = f
+}
+
+// Help describes the command and its subcommands
+func (r *CommandRouter) Help() *CommandHelp {
+	h := CommandHelp{
+		Command:     r.command,
+		Description: r.description,
+		Examples:    r.examples,
+	}
+
+	for _, s := range r.subcommands {
+		sh := SubcommandHelp{
+			Name:        s.Name,
+			Description: s.description,
+			Usage:       s.Usage(r.command),
+			Examples:    s.examples,
+		}
+		for _, a := range s.args {
+			if a.help != "" {
+				sh.Arguments = append(sh.Arguments, ArgumentHelp{Name: "<" + a.name + ">", Description: a.help})
+			}
+		}
+		if s.restHelp != "" {
+			sh.Arguments = append(sh.Arguments, ArgumentHelp{Name: "<" + s.rest + ">...", Description: s.restHelp})
+		}
+		for _, f := range s.flags {
+			if f.help != "" {
+				desc := f.help
+				if f.value != "" {
+					desc += fmt.Sprintf(" (default: %s)", f.value)
+				}
+				sh.Arguments = append(sh.Arguments, ArgumentHelp{Name: "--" + f.name, Description: desc})
+			}
+		}
+		h.Subcommands = append(h.Subcommands, sh)
+	}
+	sort.SliceStable(h.Subcommands, func(i, j int) bool { return h.Subcommands[i].Name < h.Subcommands[j].Name })
+
+	return &h
+}
+
+// Blocks renders the help of the command
+func (h *CommandHelp) Blocks() []Block {
+	blocks := []Block{&HeaderBlock{Text: TextObject{Type: "plain_text", Text: h.Command}}}
+
+	if h.Description != "" {
+		blocks = append(blocks, helpSection(Escape(h.Description)))
+	}
+	if h.Usage != "" {
+		blocks = append(blocks, helpSection(Bold("Usage")+"\n"+Code(Escape(h.Usage))))
+	}
+
+	if len(h.Subcommands) > 0 {
+		lines := make([]string, len(h.Subcommands))
+		for i, s := range h.Subcommands {
+			lines[i] = Code(Escape(s.Usage))
+			if s.Description != "" {
+				lines[i] += "\n" + Escape(s.Description)
+			}
+		}
+		blocks = append(blocks, &DividerBlock{}, helpSection(strings.Join(lines, "\n\n")))
+	}
+
+	if len(h.Examples) > 0 {
+		blocks = append(blocks, helpExamples(h.Examples))
+	}
+
+	if len(h.Subcommands) > 0 {
+		blocks = append(blocks, &ContextBlock{Elements: []ContextElement{
+			&TextObject{Type: "mrkdwn", Text: "Type " + Code(Escape(h.Command+" help <subcommand>")) + " to learn more."},
+		}})
+	}
+
+	return blocks
+}
+
+// SubcommandBlocks renders the help of a subcommand, or of the command if the subcommand is unknown
+func (h *CommandHelp) SubcommandBlocks(name string) []Block {
+	var sub *SubcommandHelp
+	for i := range h.Subcommands {
+		if h.Subcommands[i].Name == name {
+			sub = &h.Subcommands[i]
+		}
+	}
+	if name == "" || sub == nil {
+		return h.Blocks()
+	}
+
+	blocks := []Block{
+		&HeaderBlock{Text: TextObject{Type: "plain_text", Text: strings.TrimSpace(h.Command + " " + sub.Name)}},
+	}
+	if sub.Description != "" {
+		blocks = append(blocks, helpSection(Escape(sub.Description)))
+	}
+	blocks = append(blocks, helpSection(Bold("Usage")+"\n"+Code(Escape(sub.Usage))))
+
+	if len(sub.Arguments) > 0 {
+		lines := make([]string, len(sub.Arguments))
+		for i, a := range sub.Arguments {
+			lines[i] = Code(Escape(a.Name)) + " " + Escape(a.Description)
+		}
+		blocks = append(blocks, helpSection(BulletList(lines...)))
+	}
+	if len(sub.Examples) > 0 {
+		blocks = append(blocks, helpExamples(sub.Examples))
+	}
+
+	return blocks
+}
+
+// UsageErrorBlocks explains what is wrong, followed by the help of the subcommand
+func UsageErrorBlocks(h *CommandHelp, ue *UsageError) *SectionBlocks {
+	blocks := []Block{helpSection(":warning: " + Escape(ue.Message))}
+	if h == nil {
+		blocks = append(blocks, helpSection(CodeBlock(Escape(ue.Usage))))
+	} else {
+		blocks = append(blocks, h.SubcommandBlocks(ue.Subcommand)...)
+	}
+	return &SectionBlocks{Blocks: blocks}
+}
+
+// helpOverviewBlocks lists all commands with registered help
+func helpOverviewBlocks() []Block {
+	if len(slashCommandHelp) == 0 {
+		return nil
+	}
+
+	commands := make([]string, 0, len(slashCommandHelp))
+	for k := range slashCommandHelp {
+		commands = append(commands, k)
+	}
+	sort.Strings(commands)
+
+	lines := make([]string, len(commands))
+	for i, k := range commands {
+		h := slashCommandHelp[k]()
+		lines[i] = Bold(Escape(h.Command))
+		if h.Description != "" {
+			lines[i] += " " + Escape(h.Description)
+		}
+	}
+
+	return []Block{
+		helpSection("These are the commands I know:"),
+		helpSection(strings.Join(lines, "\n")),
+	}
+}
+
+func helpSection(text string) *SectionBlock {
+	return &SectionBlock{Text: TextObject{Type: "mrkdwn", Text: text}}
+}
+
+func helpExamples(examples []string) *SectionBlock {
+	lines := make([]string, len(examples))
+	for i := range examples {
+		lines[i] = Code(Escape(examples[i]))
+	}
+	return helpSection(Bold("Examples") + "\n" + strings.Join(lines, "\n"))
+}
@@ -0,0 +1,31 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRouterHelpIsResolvedLazily(t *testing.T) {
+	r := NewCommandRouter("/lazy")
+	r.Subcommand("status", nil)
+	r.Register()
+	defer delete(slashCommandHelp, "/lazy")
+	defer delete(slashCommandLookup, "/lazy")
+
+	// extended after Register
+	r.Describe("Late description")
+	r.Subcommand("deploy", nil)
+
+	h := slashCommandHelp["/lazy"]()
+	if len(h.Subcommands) != 2 || h.Subcommands[0].Name != "deploy" {
+		t.Errorf("Help() subcommands = %+v, want deploy and status", h.Subcommands)
+	}
+
+	var text []string
+	for _, b := range helpOverviewBlocks() {
+		text = append(text, b.(*SectionBlock).Text.Text)
+	}
+	if !strings.Contains(strings.Join(text, "\n"), "Late description") {
+		t.Errorf("overview = %q, want the description set after Register", text)
+	}
+}
@@ -0,0 +1,88 @@
+package slack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/datastore"
+	"golang.org/x/net/context"
+
+	"github.com/txsvc/commons/pkg/util"
+	"github.com/txsvc/platform/pkg/platform"
+	s "github.com/txsvc/platform/pkg/services"
+)
+
+// GetKV of the platform returns entries regardless of their expiration time, values with a
+// limited lifetime are stored together with their expiration time.
+
+type (
+	// expiringStore stores values with a limited lifetime
+	expiringStore interface {
+		Get(ctx context.Context, key string) (string, bool)
+		Set(ctx context.Context, key, value string, duration int64) error
+	}
+
+	// kvStore is the expiringStore backed by the KV store of the platform
+	kvStore struct{}
+)
+
+// Get implements expiringStore
+func (kvStore) Get(ctx context.Context, key string) (string, bool) {
+	return getExpiringKV(ctx, key)
+}
+
+// Set implements expiringStore
+func (kvStore) Set(ctx context.Context, key, value string, duration int64) error {
+	return setExpiringKV(ctx, key, value, duration)
+}
+
+// setExpiringKV stores a value with its expiration time, the KV store does not expire entries itself
+func setExpiringKV(ctx context.Context, key, value string, duration int64) error {
+	return s.SetKV(ctx, key, fmt.Sprintf("%d|%s", util.Timestamp()+duration, value), duration)
+}
+
+// getExpiringKV returns a value stored with setExpiringKV, expired values are not found
+func getExpiringKV(ctx context.Context, key string) (string, bool) {
+	v, err := s.GetKV(ctx, key)
+	if err != nil {
+		return "", false
+	}
+	return expiringValue(v, util.Timestamp())
+}
+
+// consumeExpiringKV returns a value stored with setExpiringKV and deletes it in the same
+// transaction. Only one of several concurrent callers gets the value.
+func consumeExpiringKV(ctx context.Context, key string) (string, bool) {
+	k := datastore.NameKey(s.DatastoreKV, key, nil)
+
+	var v string
+	_, err := platform.DataStore().RunInTransaction(ctx, func(tx *datastore.Transaction) error {
+		var kv s.KV
+		if err := tx.Get(k, &kv); err != nil {
+			return err
+		}
+		v = kv.Value
+		return tx.Delete(k)
+	})
+	if err != nil {
+		if err != datastore.ErrNoSuchEntity {
+			platform.ReportError(err)
+		}
+		return "", false
+	}
+	return expiringValue(v, util.Timestamp())
+}
+
+// expiringValue returns the value of an entry, unless it is expired or malformed
+func expiringValue(v string, now int64) (string, bool) {
+	parts := strings.SplitN(v, "|", 2)
+	if len(parts) != 2 {
+		return "", false
+	}
+	expires, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || expires <= now {
+		return "", false
+	}
+	return parts[1], true
+}
@@ -0,0 +1,29 @@
+package slack
+
+import "testing"
+
+func TestExpiringValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+		found bool
+	}{
+		{"valid", "1000|U1,U2", "U1,U2", true},
+		{"empty value", "1000|", "", true},
+		{"separator in value", "1000|a|b", "a|b", true},
+		{"expired", "500|U1", "", false},
+		{"expires now", "900|U1", "", false},
+		{"legacy value without expiration", "U1,U2", "", false},
+		{"malformed expiration", "soon|U1", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := expiringValue(tt.value, 900)
+			if got != tt.want || found != tt.found {
+				t.Errorf("expiringValue(%q) = %q, %v, want %q, %v", tt.value, got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
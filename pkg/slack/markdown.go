@@ -0,0 +1,704 @@
+package slack
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A converter for the commonly used subset of CommonMark: headings, paragraphs, emphasis,
+// strikethrough, code spans, fenced and indented code, block quotes, lists, links and rules.
+// HTML, tables and reference links are passed through as text.
+
+const (
+	mdParagraph = iota
+	mdHeading
+	mdCode
+	mdQuote
+	mdList
+	mdRule
+)
+
+type (
+	// mdBlock is a block level element of a Markdown document
+	mdBlock struct {
+		kind     int
+		text     string     // paragraph, heading and code
+		children []*mdBlock // quote
+		items    []*mdItem  // list
+	}
+
+	// mdItem is a list item, nested lists are flattened using indent
+	mdItem struct {
+		text    string
+		indent  int
+		ordered bool
+		number  int
+	}
+
+	// mdSpan is a run of text with the same style
+	mdSpan struct {
+		text   string
+		bold   bool
+		italic bool
+		strike bool
+		code   bool
+		url    string
+	}
+)
+
+var (
+	mdHeadingPattern = regexp.MustCompile(`^ {0,3}(#{1,6})(?:\s+(.*?))?(?:\s+#+)?\s*$`)
+	mdRulePattern    = regexp.MustCompile(`^ {0,3}(?:(?:\*\s*){3,}|(?:-\s*){3,}|(?:_\s*){3,})$`)
+	mdListPattern    = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])\s+(.*)$`)
+	mdFencePattern   = regexp.MustCompile("^ {0,3}(```+|~~~+)")
+	mdQuotePattern   = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+
+	mdURLReplacer = strings.NewReplacer("|", "%7C", "<", "%3C", ">", "%3E")
+)
+
+// MarkdownToMrkdwn converts CommonMark to Slack's mrkdwn
+func MarkdownToMrkdwn(md string) string {
+	return strings.Join(mrkdwnChunks(parseMarkdown(md)), "\n\n")
+}
+
+// MarkdownToSections converts CommonMark to mrkdwn section blocks. Long documents are split
+// across several sections so that no section exceeds Slack's limit of 3000 characters.
+func MarkdownToSections(md string) []Block {
+	var sections []Block
+	current := ""
+
+	flush := func() {
+		if current != "" {
+			sections = append(sections, &SectionBlock{Text: TextObject{Type: "mrkdwn", Text: current}})
+			current = ""
+		}
+	}
+
+	for _, chunk := range mrkdwnChunks(parseMarkdown(md)) {
+		for _, part := range splitChunk(chunk, maxSectionText) {
+			if current != "" && utf8.RuneCountInString(current)+2+utf8.RuneCountInString(part) > maxSectionText {
+				flush()
+			}
+			if current == "" {
+				current = part
+			} else {
+				current += "\n\n" + part
+			}
+		}
+	}
+	flush()
+
+	return sections
+}
+
+// MarkdownToRichText converts CommonMark to a rich_text block
+func MarkdownToRichText(md string) *RichTextBlock {
+	rt := RichTextBlock{}
+	appendRichText(&rt, parseMarkdown(md))
+	return &rt
+}
+
+// parseMarkdown splits a document into block level elements
+func parseMarkdown(md string) []*mdBlock {
+	lines := strings.Split(strings.Replace(md, "\r\n", "\n", -1), "\n")
+	var blocks []*mdBlock
+	var paragraph []string
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, &mdBlock{kind: mdParagraph, text: joinParagraph(paragraph)})
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if strings.TrimSpace(line) == "" {
+			flushParagraph()
+			continue
+		}
+
+		// fenced code
+		if m := mdFencePattern.FindStringSubmatch(line); m != nil {
+			flushParagraph()
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]) {
+					break
+				}
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, &mdBlock{kind: mdCode, text: strings.Join(code, "\n")})
+			continue
+		}
+
+		// indented code, but not as continuation of a paragraph
+		if len(paragraph) == 0 && (strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")) && !mdListPattern.MatchString(line) {
+			var code []string
+			for ; i < len(lines); i++ {
+				l := lines[i]
+				if strings.TrimSpace(l) != "" && !strings.HasPrefix(l, "    ") && !strings.HasPrefix(l, "\t") {
+					break
+				}
+				code = append(code, strings.TrimPrefix(strings.TrimPrefix(l, "\t"), "    "))
+			}
+			i--
+			blocks = append(blocks, &mdBlock{kind: mdCode, text: strings.TrimRight(strings.Join(code, "\n"), "\n")})
+			continue
+		}
+
+		if m := mdHeadingPattern.FindStringSubmatch(line); m != nil {
+			flushParagraph()
+			blocks = append(blocks, &mdBlock{kind: mdHeading, text: m[2]})
+			continue
+		}
+
+		if mdRulePattern.MatchString(line) {
+			flushParagraph()
+			blocks = append(blocks, &mdBlock{kind: mdRule})
+			continue
+		}
+
+		if mdQuotePattern.MatchString(line) {
+			flushParagraph()
+			var quoted []string
+			for ; i < len(lines); i++ {
+				m := mdQuotePattern.FindStringSubmatch(lines[i])
+				if m == nil {
+					break
+				}
+				quoted = append(quoted, m[1])
+			}
+			i--
+			blocks = append(blocks, &mdBlock{kind: mdQuote, children: parseMarkdown(strings.Join(quoted, "\n"))})
+			continue
+		}
+
+		if mdListPattern.MatchString(line) {
+			flushParagraph()
+			list := &mdBlock{kind: mdList}
+			var open []int // content columns of the enclosing items
+			for ; i < len(lines); i++ {
+				l := lines[i]
+				if m := mdListPattern.FindStringSubmatch(l); m != nil && !mdRulePattern.MatchString(l) {
+					// an item is nested if its marker starts within the content of an open item
+					col := columnWidth(m[1])
+					for len(open) > 0 && col < open[len(open)-1] {
+						open = open[:len(open)-1]
+					}
+					item := &mdItem{text: m[3], indent: len(open)}
+					open = append(open, columnWidth(l[:len(l)-len(m[3])]))
+					if n, err := strconv.Atoi(strings.TrimRight(m[2], ".)")); err == nil {
+						item.ordered = true
+						item.number = n
+					}
+					list.items = append(list.items, item)
+					continue
+				}
+				// lazy continuation of the last item
+				if strings.TrimSpace(l) != "" && len(list.items) > 0 && !mdHeadingPattern.MatchString(l) && !mdFencePattern.MatchString(l) && !mdQuotePattern.MatchString(l) {
+					last := list.items[len(list.items)-1]
+					last.text += " " + strings.TrimSpace(l)
+					continue
+				}
+				break
+			}
+			i--
+			blocks = append(blocks, list)
+			continue
+		}
+
+		paragraph = append(paragraph, line)
+	}
+	flushParagraph()
+
+	return blocks
+}
+
+// mrkdwnChunks renders each block as mrkdwn
+func mrkdwnChunks(blocks []*mdBlock) []string {
+	chunks := make([]string, 0, len(blocks))
+
+	for _, b := range blocks {
+		switch b.kind {
+		case mdParagraph:
+			chunks = append(chunks, spansToMrkdwn(parseInline(b.text)))
+		case mdHeading:
+			chunks = append(chunks, Bold(spansToMrkdwn(plainStyle(parseInline(b.text)))))
+		case mdCode:
+			chunks = append(chunks, CodeBlock(Escape(b.text)))
+		case mdQuote:
+			chunks = append(chunks, Quote(strings.Join(mrkdwnChunks(b.children), "\n\n")))
+		case mdRule:
+			chunks = append(chunks, "―――――――――――")
+		case mdList:
+			lines := make([]string, len(b.items))
+			for i, item := range b.items {
+				bullet := "•"
+				if item.ordered {
+					bullet = strconv.Itoa(item.number) + "."
+				}
+				lines[i] = strings.Repeat("    ", item.indent) + bullet + " " + spansToMrkdwn(parseInline(item.text))
+			}
+			chunks = append(chunks, strings.Join(lines, "\n"))
+		}
+	}
+
+	return chunks
+}
+
+// appendRichText renders blocks as rich text elements
+func appendRichText(rt *RichTextBlock, blocks []*mdBlock) {
+	var section *RichTextSection
+
+	flush := func() {
+		if section != nil {
+			rt.Elements = append(rt.Elements, section)
+			section = nil
+		}
+	}
+	paragraph := func(spans []mdSpan) {
+		if section == nil {
+			section = &RichTextSection{}
+		} else {
+			section.Elements = append(section.Elements, &RichTextText{Text: "\n\n"})
+		}
+		section.Elements = append(section.Elements, spansToRichText(spans)...)
+	}
+
+	for _, b := range blocks {
+		switch b.kind {
+		case mdParagraph:
+			paragraph(parseInline(b.text))
+		case mdHeading:
+			spans := parseInline(b.text)
+			for i := range spans {
+				spans[i].bold = true
+			}
+			paragraph(spans)
+		case mdRule:
+			paragraph([]mdSpan{{text: "―――――――――――"}})
+		case mdCode:
+			flush()
+			rt.Elements = append(rt.Elements, &RichTextPreformatted{Elements: []RichTextInline{&RichTextText{Text: b.text}}})
+		case mdQuote:
+			flush()
+			var quote RichTextBlock
+			appendRichText(&quote, b.children)
+			rt.Elements = append(rt.Elements, &RichTextQuote{Elements: flattenRichText(quote.Elements)})
+		case mdList:
+			flush()
+			var list *RichTextList
+			for _, item := range b.items {
+				style := "bullet"
+				if item.ordered {
+					style = "ordered"
+				}
+				if list == nil || list.Indent != item.indent || list.Style != style {
+					list = &RichTextList{Style: style, Indent: item.indent}
+					if item.ordered && item.number > 1 {
+						list.Offset = item.number - 1
+					}
+					rt.Elements = append(rt.Elements, list)
+				}
+				list.Elements = append(list.Elements, RichTextSection{Elements: spansToRichText(parseInline(item.text))})
+			}
+		}
+	}
+	flush()
+}
+
+// flattenRichText turns nested elements into inline elements, e.g. for quotes
+func flattenRichText(elements []RichTextElement) []RichTextInline {
+	var inline []RichTextInline
+	for i, e := range elements {
+		if i > 0 {
+			inline = append(inline, &RichTextText{Text: "\n"})
+		}
+		switch e := e.(type) {
+		case *RichTextSection:
+			inline = append(inline, e.Elements...)
+		case *RichTextPreformatted:
+			for _, x := range e.Elements {
+				if t, ok := x.(*RichTextText); ok {
+					inline = append(inline, &RichTextText{Text: t.Text, Style: &RichTextStyle{Code: true}})
+				}
+			}
+		case *RichTextQuote:
+			inline = append(inline, e.Elements...)
+		case *RichTextList:
+			for j, item := range e.Elements {
+				if j > 0 {
+					inline = append(inline, &RichTextText{Text: "\n"})
+				}
+				inline = append(inline, &RichTextText{Text: strings.Repeat("    ", e.Indent) + "• "})
+				inline = append(inline, item.Elements...)
+			}
+		}
+	}
+	return inline
+}
+
+// parseInline splits text into styled spans
+func parseInline(s string) []mdSpan {
+	return parseInlineStyled(s, mdSpan{})
+}
+
+func parseInlineStyled(s string, style mdSpan) []mdSpan {
+	var spans []mdSpan
+	var text strings.Builder
+
+	emit := func() {
+		if text.Len() > 0 {
+			span := style
+			span.text = text.String()
+			spans = append(spans, span)
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case c == '\\' && i+1 < len(s) && unicode.IsPunct(rune(s[i+1])) || c == '\\' && i+1 < len(s) && unicode.IsSymbol(rune(s[i+1])):
+			text.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			n := countRun(s[i:], '`')
+			delim := strings.Repeat("`", n)
+			if end := strings.Index(s[i+n:], delim); end >= 0 {
+				emit()
+				span := style
+				span.code = true
+				span.text = strings.TrimSpace(s[i+n : i+n+end])
+				spans = append(spans, span)
+				i += n + end + n
+				continue
+			}
+
+		case c == '[':
+			if label, url, n, ok := parseLink(s[i:]); ok {
+				emit()
+				linkStyle := style
+				linkStyle.url = url
+				inner := parseInlineStyled(label, linkStyle)
+				if len(inner) == 0 {
+					linkStyle.text = url
+					inner = []mdSpan{linkStyle}
+				}
+				spans = append(spans, inner...)
+				i += n
+				continue
+			}
+
+		case c == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 0 {
+				target := s[i+1 : i+end]
+				if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "mailto:") {
+					emit()
+					span := style
+					span.url = target
+					span.text = strings.TrimPrefix(target, "mailto:")
+					spans = append(spans, span)
+					i += end + 1
+					continue
+				}
+			}
+
+		case strings.HasPrefix(s[i:], "**") || strings.HasPrefix(s[i:], "__"):
+			delim := s[i : i+2]
+			if end := strings.Index(s[i+2:], delim); end > 0 && canOpen(s, i, 2) {
+				emit()
+				inner := style
+				inner.bold = true
+				spans = append(spans, parseInlineStyled(s[i+2:i+2+end], inner)...)
+				i += 2 + end + 2
+				continue
+			}
+
+		case strings.HasPrefix(s[i:], "~~"):
+			if end := strings.Index(s[i+2:], "~~"); end > 0 {
+				emit()
+				inner := style
+				inner.strike = true
+				spans = append(spans, parseInlineStyled(s[i+2:i+2+end], inner)...)
+				i += 2 + end + 2
+				continue
+			}
+
+		case c == '*' || c == '_':
+			if end := findCloser(s, i+1, c); end > i+1 && canOpen(s, i, 1) {
+				emit()
+				inner := style
+				inner.italic = true
+				spans = append(spans, parseInlineStyled(s[i+1:end], inner)...)
+				i = end + 1
+				continue
+			}
+		}
+
+		text.WriteByte(c)
+		i++
+	}
+	emit()
+
+	return spans
+}
+
+// parseLink parses [label](url "title") and returns the label, url and length
+func parseLink(s string) (string, string, int, bool) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				if i+1 >= len(s) || s[i+1] != '(' {
+					return "", "", 0, false
+				}
+				end := strings.IndexByte(s[i+2:], ')')
+				if end < 0 {
+					return "", "", 0, false
+				}
+				target := strings.TrimSpace(s[i+2 : i+2+end])
+				if sp := strings.IndexAny(target, " \t"); sp >= 0 {
+					target = target[:sp] // drop the title
+				}
+				return s[1:i], strings.Trim(target, "<>"), i + 2 + end + 1, true
+			}
+		}
+	}
+	return "", "", 0, false
+}
+
+// findCloser returns the index of the single delimiter c closing emphasis opened before start,
+// skipping nested runs like ** and code spans. It returns -1 if there is none.
+func findCloser(s string, start int, c byte) int {
+	for i := start; i < len(s); {
+		switch {
+		case s[i] == '\\':
+			i += 2
+		case s[i] == '`':
+			n := countRun(s[i:], '`')
+			end := strings.Index(s[i+n:], strings.Repeat("`", n))
+			if end < 0 {
+				i += n
+			} else {
+				i += n + end + n
+			}
+		case s[i] == c:
+			n := countRun(s[i:], c)
+			if n == 1 && canClose(s, i) {
+				return i
+			}
+			i += n
+		default:
+			i++
+		}
+	}
+	return -1
+}
+
+// canOpen prevents intraword underscores like snake_case from starting emphasis
+func canOpen(s string, i, n int) bool {
+	if i+n >= len(s) || s[i+n] == ' ' {
+		return false
+	}
+	if s[i] == '_' && i > 0 && isWordChar(s[i-1]) {
+		return false
+	}
+	return true
+}
+
+func canClose(s string, i int) bool {
+	if i == 0 || s[i-1] == ' ' {
+		return false
+	}
+	if s[i] == '_' && i+1 < len(s) && isWordChar(s[i+1]) {
+		return false
+	}
+	return true
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func countRun(s string, c byte) int {
+	n := 0
+	for n < len(s) && s[n] == c {
+		n++
+	}
+	return n
+}
+
+// spansToMrkdwn renders spans, consecutive spans with the same URL become one link
+func spansToMrkdwn(spans []mdSpan) string {
+	var b strings.Builder
+
+	for i := 0; i < len(spans); {
+		if spans[i].url == "" {
+			b.WriteString(styleMrkdwn(spans[i]))
+			i++
+			continue
+		}
+
+		url := spans[i].url
+		var label strings.Builder
+		for ; i < len(spans) && spans[i].url == url; i++ {
+			label.WriteString(styleMrkdwn(spans[i]))
+		}
+		if label.String() == Escape(url) {
+			b.WriteString("<" + mrkdwnURL(url) + ">")
+		} else {
+			b.WriteString("<" + mrkdwnURL(url) + "|" + label.String() + ">")
+		}
+	}
+
+	return b.String()
+}
+
+// mrkdwnURL percent-encodes the characters that would end the link target in <url|text>
+func mrkdwnURL(url string) string {
+	return mdURLReplacer.Replace(url)
+}
+
+func styleMrkdwn(span mdSpan) string {
+	s := Escape(span.text)
+	if span.code {
+		return Code(s)
+	}
+	if span.strike {
+		s = Strike(s)
+	}
+	if span.italic {
+		s = Italic(s)
+	}
+	if span.bold {
+		s = Bold(s)
+	}
+	return s
+}
+
+func spansToRichText(spans []mdSpan) []RichTextInline {
+	elements := make([]RichTextInline, 0, len(spans))
+
+	for _, span := range spans {
+		var style *RichTextStyle
+		if span.bold || span.italic || span.strike || span.code {
+			style = &RichTextStyle{Bold: span.bold, Italic: span.italic, Strike: span.strike, Code: span.code}
+		}
+		if span.url != "" {
+			elements = append(elements, &RichTextLink{URL: span.url, Text: span.text, Style: style})
+		} else {
+			elements = append(elements, &RichTextText{Text: span.text, Style: style})
+		}
+	}
+
+	return elements
+}
+
+// plainStyle removes bold from spans, e.g. in headings that are rendered bold anyways
+func plainStyle(spans []mdSpan) []mdSpan {
+	for i := range spans {
+		spans[i].bold = false
+	}
+	return spans
+}
+
+// splitChunk splits a rendered block at line boundaries, and lines that are too long at spaces.
+// Code blocks are closed and reopened.
+func splitChunk(chunk string, max int) []string {
+	if utf8.RuneCountInString(chunk) <= max {
+		return []string{chunk}
+	}
+
+	code := strings.HasPrefix(chunk, "```\n") && strings.HasSuffix(chunk, "\n```")
+	if code {
+		chunk = strings.TrimSuffix(strings.TrimPrefix(chunk, "```\n"), "\n```")
+		max -= 8 // room for the fences
+	}
+
+	var parts []string
+	current := ""
+	for _, line := range strings.Split(chunk, "\n") {
+		for utf8.RuneCountInString(line) > max {
+			r := []rune(line)
+			if current != "" {
+				parts = append(parts, current)
+				current = ""
+			}
+			cut, next := max, max
+			if !code {
+				// break at the last space that fits, unless that leaves a very short part
+				for j := max; j > max/2; j-- {
+					if r[j] == ' ' {
+						cut, next = j, j+1
+						break
+					}
+				}
+			}
+			parts = append(parts, string(r[:cut]))
+			line = string(r[next:])
+		}
+		if current != "" && utf8.RuneCountInString(current)+1+utf8.RuneCountInString(line) > max {
+			parts = append(parts, current)
+			current = ""
+		}
+		if current == "" {
+			current = line
+		} else {
+			current += "\n" + line
+		}
+	}
+	if current != "" {
+		parts = append(parts, current)
+	}
+
+	if code {
+		for i := range parts {
+			parts[i] = "```\n" + parts[i] + "\n```"
+		}
+	}
+	return parts
+}
+
+// joinParagraph joins the lines of a paragraph, keeping hard line breaks
+func joinParagraph(lines []string) string {
+	var b strings.Builder
+	for i, l := range lines {
+		hard := strings.HasSuffix(l, "  ") || strings.HasSuffix(l, "\\")
+		l = strings.TrimSpace(strings.TrimSuffix(l, "\\"))
+		b.WriteString(l)
+		if i < len(lines)-1 {
+			if hard {
+				b.WriteString("\n")
+			} else {
+				b.WriteString(" ")
+			}
+		}
+	}
+	return b.String()
+}
+
+// columnWidth returns the column after s, tabs advance to the next multiple of 4
+func columnWidth(s string) int {
+	n := 0
+	for _, c := range s {
+		if c == '\t' {
+			n += 4 - n%4
+		} else {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,173 @@
+package slack
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMarkdownToMrkdwn(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{"bold", "a **b** c", "a *b* c"},
+		{"italic", "a *b* _c_", "a _b_ _c_"},
+		{"strike", "~~gone~~", "~gone~"},
+		{"code", "use `a*b*c`", "use `a*b*c`"},
+		{"escape", "a < b & c", "a &lt; b &amp; c"},
+		{"snake_case", "snake_case_name", "snake_case_name"},
+		{"nested bold in italic", "*a **b** c*", "_a_ *_b_* _c_"},
+		{"nested italic in bold", "**a *b* c**", "*a* *_b_* *c*"},
+		{"unclosed emphasis", "2 * 3 = 6", "2 * 3 = 6"},
+		{"link", "[docs](https://example.com)", "<https://example.com|docs>"},
+		{"autolink", "<https://example.com>", "<https://example.com>"},
+		{"link target with pipe", "[x](https://example.com/?a=1|2)", "<https://example.com/?a=1%7C2|x>"},
+		{"link target with angle brackets", "[x](<https://example.com/a>b>)", "<https://example.com/a%3Eb|x>"},
+		{"heading", "# Title", "*Title*"},
+		{"list", "- one\n- two", "• one\n• two"},
+		{"quote", "> quoted", ">quoted"},
+		{"paragraphs", "one\n\ntwo", "one\n\ntwo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToMrkdwn(tt.md); got != tt.want {
+				t.Errorf("MarkdownToMrkdwn(%q) = %q, want %q", tt.md, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownNestedLists(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{"two spaces", "- a\n  - b\n    - c\n- d", "• a\n    • b\n        • c\n• d"},
+		{"four spaces", "- a\n    - b\n- c", "• a\n    • b\n• c"},
+		{"tab", "- a\n\t- b", "• a\n    • b"},
+		{"under an ordered item", "1. one\n   - sub\n2. two", "1. one\n    • sub\n2. two"},
+		{"not nested", "- a\n - b", "• a\n• b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToMrkdwn(tt.md); got != tt.want {
+				t.Errorf("MarkdownToMrkdwn(%q) = %q, want %q", tt.md, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownToSections(t *testing.T) {
+	paragraph := strings.TrimSpace(strings.Repeat("lorem ipsum ", 100)) // 1199 characters
+	longParagraph := strings.TrimSpace(strings.Repeat("lorem ipsum ", 400))
+	code := "```\n" + strings.TrimSpace(strings.Repeat("fmt.Println(42)\n", 250)) + "\n```"
+
+	tests := []struct {
+		name     string
+		md       string
+		sections int
+		check    func(t *testing.T, texts []string)
+	}{
+		{"short", "# Title\n\ntext", 1, nil},
+		{"split on paragraphs", strings.Repeat(paragraph+"\n\n", 4), 2, func(t *testing.T, texts []string) {
+			for _, s := range texts {
+				if strings.HasPrefix(s, " ") || strings.HasSuffix(s, "lorem") || !strings.HasSuffix(s, "ipsum") {
+					t.Errorf("section does not end on a paragraph boundary: ...%q", s[len(s)-20:])
+				}
+			}
+		}},
+		{"long paragraph", longParagraph, 2, func(t *testing.T, texts []string) {
+			if got := strings.Join(texts, " "); got != longParagraph {
+				t.Error("words were lost or split")
+			}
+		}},
+		{"code block across the split", paragraph + "\n\n" + code, 3, func(t *testing.T, texts []string) {
+			for _, s := range texts[1:] {
+				if !strings.HasPrefix(s, "```\n") || !strings.HasSuffix(s, "\n```") {
+					t.Errorf("code block is not closed and reopened: %q...%q", s[:10], s[len(s)-10:])
+				}
+			}
+			lines := 0
+			for _, s := range texts[1:] {
+				lines += strings.Count(s, "fmt.Println(42)")
+			}
+			if lines != 250 {
+				t.Errorf("code lines = %d, want 250", lines)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := MarkdownToSections(tt.md)
+			if len(blocks) != tt.sections {
+				t.Fatalf("sections = %d, want %d", len(blocks), tt.sections)
+			}
+
+			texts := make([]string, len(blocks))
+			for i, b := range blocks {
+				texts[i] = b.(*SectionBlock).Text.Text
+				if n := utf8.RuneCountInString(texts[i]); n > maxSectionText {
+					t.Errorf("section %d has %d characters", i, n)
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, texts)
+			}
+		})
+	}
+}
+
+func TestMarkdownToRichText(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{
+			"paragraph with link",
+			"see [docs](https://example.com) **now**",
+			`[{"type":"rich_text_section","elements":[{"type":"text","text":"see "},{"type":"link","url":"https://example.com","text":"docs"},{"type":"text","text":" "},{"type":"text","text":"now","style":{"bold":true}}]}]`,
+		},
+		{
+			"nested lists",
+			"- a\n    - b\n- c",
+			`[{"type":"rich_text_list","style":"bullet","elements":[{"type":"rich_text_section","elements":[{"type":"text","text":"a"}]}]},` +
+				`{"type":"rich_text_list","style":"bullet","elements":[{"type":"rich_text_section","elements":[{"type":"text","text":"b"}]}],"indent":1},` +
+				`{"type":"rich_text_list","style":"bullet","elements":[{"type":"rich_text_section","elements":[{"type":"text","text":"c"}]}]}]`,
+		},
+		{
+			"ordered list",
+			"1. one\n2. two",
+			`[{"type":"rich_text_list","style":"ordered","elements":[{"type":"rich_text_section","elements":[{"type":"text","text":"one"}]},{"type":"rich_text_section","elements":[{"type":"text","text":"two"}]}]}]`,
+		},
+		{
+			"quote",
+			"> quoted *text*",
+			`[{"type":"rich_text_quote","elements":[{"type":"text","text":"quoted "},{"type":"text","text":"text","style":{"italic":true}}]}]`,
+		},
+		{
+			"code",
+			"```\nx := 1\n```",
+			`[{"type":"rich_text_preformatted","elements":[{"type":"text","text":"x := 1"}]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(MarkdownToRichText(tt.md).Elements)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("MarkdownToRichText(%q) =\n%s\nwant\n%s", tt.md, b, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/txsvc/platform/pkg/platform"
+)
+
+// Types of interactions
+const (
+	InteractionSlashCommand   = "slash_command"
+	InteractionMessageAction  = "message_action"
+	InteractionViewSubmission = "view_submission"
+)
+
+type (
+	// Interaction is a request from Slack that is dispatched to a registered handler.
+	// Exactly one of Command, Action and Submission is set, depending on Type.
+	Interaction struct {
+		Type       string
+		Name       string // the command or action the handler is registered for
+		Command    *SlashCommand
+		Action     *ActionRequest
+		Submission *ViewSubmission
+	}
+
+	// InteractionFunc handles an interaction. The message returned is the response: it is sent
+	// to the response_url of message actions, or as ephemeral message or modal if there is none.
+	// For view submissions it is pushed as a modal on top of the submitted view, which stays open.
+	InteractionFunc func(c *gin.Context, i *Interaction) (*SectionBlocks, error)
+
+	// Middleware wraps the handler of an interaction. It can inspect the interaction before and
+	// after calling next, or short-circuit by returning a response without calling next.
+	Middleware func(next InteractionFunc) InteractionFunc
+)
+
+var globalMiddleware []Middleware
+var handlerMiddleware map[string][]Middleware
+
+// Use adds middleware to all slash command and action handlers. Global middleware runs
+// before middleware added for a specific command or action.
+func Use(mw ...Middleware) {
+	globalMiddleware = append(globalMiddleware, mw...)
+}
+
+// UseSlashCmd adds middleware to the handler of a slash command
+func UseSlashCmd(cmd string, mw ...Middleware) {
+	key := middlewareKey(InteractionSlashCommand, cmd)
+	handlerMiddleware[key] = append(handlerMiddleware[key], mw...)
+}
+
+// UseAction adds middleware to the start and complete handlers of an action
+func UseAction(action string, mw ...Middleware) {
+	key := middlewareKey(InteractionMessageAction, action)
+	handlerMiddleware[key] = append(handlerMiddleware[key], mw...)
+}
+
+// Recovery returns middleware that turns a panic in a handler into an error
+func Recovery() Middleware {
+	return func(next InteractionFunc) InteractionFunc {
+		return func(c *gin.Context, i *Interaction) (resp *SectionBlocks, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("panic in %s handler '%s': %v", i.Type, i.Name, r)
+					platform.ReportError(err)
+					resp = nil
+				}
+			}()
+			return next(c, i)
+		}
+	}
+}
+
+// TeamID returns the ID of the team the interaction originates from
+func (i *Interaction) TeamID() string {
+	switch {
+	case i.Command != nil:
+		return i.Command.TeamID
+	case i.Action != nil:
+		return i.Action.TeamID()
+	case i.Submission != nil:
+		return i.Submission.TeamID()
+	}
+	return ""
+}
+
+// ClientID returns the ID the installation handling the interaction is stored under
+func (i *Interaction) ClientID() string {
+	switch {
+	case i.Command != nil:
+		return i.Command.ClientID()
+	case i.Action != nil:
+		return i.Action.ClientID()
+	case i.Submission != nil:
+		return i.Submission.ClientID()
+	}
+	return ""
+}
+
+// EnterpriseID returns the ID of the Enterprise Grid organization, if any
+func (i *Interaction) EnterpriseID() string {
+	switch {
+	case i.Command != nil:
+		return i.Command.EnterpriseID
+	case i.Action != nil && i.Action.Enterprise != nil:
+		return i.Action.Enterprise.ID
+	case i.Submission != nil && i.Submission.Enterprise != nil:
+		return i.Submission.Enterprise.ID
+	}
+	return ""
+}
+
+// UserID returns the ID of the user who triggered the interaction
+func (i *Interaction) UserID() string {
+	switch {
+	case i.Command != nil:
+		return i.Command.UserID
+	case i.Action != nil && i.Action.User != nil:
+		return i.Action.User.ID
+	case i.Submission != nil && i.Submission.User != nil:
+		return i.Submission.User.ID
+	}
+	return ""
+}
+
+// ChannelID returns the ID of the channel the interaction was triggered in, if any
+func (i *Interaction) ChannelID() string {
+	switch {
+	case i.Command != nil:
+		return i.Command.ChannelID
+	case i.Action != nil && i.Action.Channel != nil:
+		return i.Action.Channel.ID
+	}
+	return ""
+}
+
+// runInteraction calls the handler wrapped in the global middleware and the handler's own middleware
+func runInteraction(c *gin.Context, i *Interaction, h InteractionFunc) (*SectionBlocks, error) {
+	kind := i.Type
+	if kind == InteractionViewSubmission {
+		kind = InteractionMessageAction // UseAction covers start and completion
+	}
+	own := handlerMiddleware[middlewareKey(kind, i.Name)]
+
+	for j := len(own) - 1; j >= 0; j-- {
+		h = own[j](h)
+	}
+	for j := len(globalMiddleware) - 1; j >= 0; j-- {
+		h = globalMiddleware[j](h)
+	}
+	return h(c, i)
+}
+
+func middlewareKey(kind, name string) string {
+	return kind + ":" + strings.ToLower(name)
+}
@@ -17,7 +17,7 @@ type (
 		ResponseMetadata MessageArray `json:"response_metadata,omitempty"`
 	}
 
-	// ViewElement defines a modal view. See https://api.slack.com/surfaces/modals/using#composing_views
+	// ViewElement defines a modal or Home tab view. See https://api.slack.com/surfaces/modals/using#composing_views
 	ViewElement struct {
 		ID                 string              `json:"id,omitempty"`
 		TeamID             string              `json:"team_id,omitempty"`
@@ -25,7 +25,7 @@ type (
 		Title              DefaultViewElement  `json:"title"`
 		Submit             *DefaultViewElement `json:"submit,omitempty"`
 		Close              *DefaultViewElement `json:"close,omitempty"`
-		Blocks             []interface{}       `json:"blocks"`
+		Blocks             []Block             `json:"blocks"`
 		PrivateMetadata    string              `json:"private_metadata,omitempty"`
 		CallbackID         string              `json:"callback_id,omitempty"`
 		State              *StateValues        `json:"state,omitempty"`
@@ -43,9 +43,21 @@ type (
 
 	// ValueObject see https://api.slack.com/reference/interaction-payloads/views#view_submission_fields
 	ValueObject struct {
-		Type    string          `json:"type"`
-		Option  *OptionsObject  `json:"selected_option,omitempty"`
-		Options []OptionsObject `json:"selected_options,omitempty"`
+		Type                  string          `json:"type"`
+		Value                 *string         `json:"value,omitempty"`
+		Option                *OptionsObject  `json:"selected_option,omitempty"`
+		Options               []OptionsObject `json:"selected_options,omitempty"`
+		SelectedDate          string          `json:"selected_date,omitempty"`
+		SelectedTime          string          `json:"selected_time,omitempty"`
+		SelectedDateTime      int64           `json:"selected_date_time,omitempty"`
+		Timezone              string          `json:"timezone,omitempty"`
+		SelectedUser          string          `json:"selected_user,omitempty"`
+		SelectedUsers         []string        `json:"selected_users,omitempty"`
+		SelectedConversation  string          `json:"selected_conversation,omitempty"`
+		SelectedConversations []string        `json:"selected_conversations,omitempty"`
+		SelectedChannel       string          `json:"selected_channel,omitempty"`
+		SelectedChannels      []string        `json:"selected_channels,omitempty"`
+		RichTextValue         *RichTextBlock  `json:"rich_text_value,omitempty"`
 	}
 
 	// ResponseMetadata map[string]string `json:"response_metadata,omitempty"`
@@ -64,12 +76,31 @@ type (
 		BlockID   string       `json:"block_id,omitempty"`
 		Text      TextObject   `json:"text"`
 		Fields    []TextObject `json:"fields,omitempty"`
-		Accessory interface{}  `json:"accessory,omitempty"`
+		Accessory BlockElement `json:"accessory,omitempty"`
 	}
 
-	// SectionBlocks is an array of SectionBlocks
+	// SectionBlocks is a message, used as response to slash commands and actions.
+	// See https://api.slack.com/interactivity/handling#message_responses
 	SectionBlocks struct {
-		Blocks []SectionBlock `json:"blocks"`
+		ResponseType    string              `json:"response_type,omitempty"` // in_channel or ephemeral (default)
+		Text            string              `json:"text,omitempty"`          // fallback for notifications
+		Blocks          []Block             `json:"blocks,omitempty"`
+		Attachments     []MessageAttachment `json:"attachments,omitempty"`
+		ThreadTS        string              `json:"thread_ts,omitempty"`
+		ReplaceOriginal bool                `json:"replace_original,omitempty"`
+		DeleteOriginal  bool                `json:"delete_original,omitempty"`
+	}
+
+	// MessageAttachment see https://api.slack.com/reference/messaging/attachments
+	MessageAttachment struct {
+		Color     string  `json:"color,omitempty"`
+		Fallback  string  `json:"fallback,omitempty"`
+		Pretext   string  `json:"pretext,omitempty"`
+		Title     string  `json:"title,omitempty"`
+		TitleLink string  `json:"title_link,omitempty"`
+		Text      string  `json:"text,omitempty"`
+		Footer    string  `json:"footer,omitempty"`
+		Blocks    []Block `json:"blocks,omitempty"`
 	}
 
 	// DividerBlock see https://api.slack.com/reference/block-kit/blocks#divider
@@ -79,15 +110,76 @@ type (
 		BlockID string `json:"block_id,omitempty"`
 	}
 
+	// HeaderBlock see https://api.slack.com/reference/block-kit/blocks#header
+	// type == header
+	HeaderBlock struct {
+		Type    string     `json:"type"`
+		BlockID string     `json:"block_id,omitempty"`
+		Text    TextObject `json:"text"` // plain_text only
+	}
+
+	// ContextBlock see https://api.slack.com/reference/block-kit/blocks#context
+	// type == context
+	ContextBlock struct {
+		Type     string           `json:"type"`
+		BlockID  string           `json:"block_id,omitempty"`
+		Elements []ContextElement `json:"elements"` // TextObject and ImageElement
+	}
+
+	// ImageBlock see https://api.slack.com/reference/block-kit/blocks#image
+	// type == image
+	ImageBlock struct {
+		Type      string           `json:"type"`
+		BlockID   string           `json:"block_id,omitempty"`
+		ImageURL  string           `json:"image_url,omitempty"`
+		SlackFile *SlackFileObject `json:"slack_file,omitempty"`
+		AltText   string           `json:"alt_text"`
+		Title     *TextObject      `json:"title,omitempty"` // plain_text only
+	}
+
+	// ActionsBlock see https://api.slack.com/reference/block-kit/blocks#actions
+	// type == actions
+	ActionsBlock struct {
+		Type     string         `json:"type"`
+		BlockID  string         `json:"block_id,omitempty"`
+		Elements []BlockElement `json:"elements"`
+	}
+
+	// FileBlock see https://api.slack.com/reference/block-kit/blocks#file
+	// type == file
+	FileBlock struct {
+		Type       string `json:"type"`
+		BlockID    string `json:"block_id,omitempty"`
+		ExternalID string `json:"external_id"`
+		Source     string `json:"source"` // always 'remote'
+	}
+
+	// VideoBlock see https://api.slack.com/reference/block-kit/blocks#video
+	// type == video
+	VideoBlock struct {
+		Type            string      `json:"type"`
+		BlockID         string      `json:"block_id,omitempty"`
+		AltText         string      `json:"alt_text"`
+		AuthorName      string      `json:"author_name,omitempty"`
+		Description     *TextObject `json:"description,omitempty"` // plain_text only
+		ProviderIconURL string      `json:"provider_icon_url,omitempty"`
+		ProviderName    string      `json:"provider_name,omitempty"`
+		Title           TextObject  `json:"title"` // plain_text only
+		TitleURL        string      `json:"title_url,omitempty"`
+		ThumbnailURL    string      `json:"thumbnail_url"`
+		VideoURL        string      `json:"video_url"`
+	}
+
 	// InputBlock see https://api.slack.com/reference/block-kit/blocks#input
 	// type == input
 	InputBlock struct {
-		Type     string      `json:"type"`
-		BlockID  string      `json:"block_id,omitempty"`
-		Label    TextObject  `json:"label"`
-		Element  interface{} `json:"element"`
-		Hint     *TextObject `json:"hint,omitempty"`
-		Optional bool        `json:"optional,omitempty"`
+		Type           string       `json:"type"`
+		BlockID        string       `json:"block_id,omitempty"`
+		Label          TextObject   `json:"label"`
+		Element        BlockElement `json:"element"`
+		DispatchAction bool         `json:"dispatch_action,omitempty"`
+		Hint           *TextObject  `json:"hint,omitempty"`
+		Optional       bool         `json:"optional,omitempty"`
 	}
 
 	// Checkboxes see https://api.slack.com/reference/block-kit/block-elements#checkboxes
@@ -98,6 +190,7 @@ type (
 		Options        []OptionsObject `json:"options"`
 		InitialOptions []OptionsObject `json:"initial_options,omitempty"`
 		Confirm        *ConfirmObject  `json:"confirm,omitempty"`
+		FocusOnLoad    bool            `json:"focus_on_load,omitempty"`
 	}
 
 	// Radiobuttons see https://api.slack.com/reference/block-kit/block-elements#radio
@@ -108,6 +201,16 @@ type (
 		Options       []OptionsObject `json:"options"`
 		InitialOption *OptionsObject  `json:"initial_option,omitempty"`
 		Confirm       *ConfirmObject  `json:"confirm,omitempty"`
+		FocusOnLoad   bool            `json:"focus_on_load,omitempty"`
+	}
+
+	// ImageElement see https://api.slack.com/reference/block-kit/block-elements#image
+	// type == image
+	ImageElement struct {
+		Type      string           `json:"type"`
+		ImageURL  string           `json:"image_url,omitempty"`
+		SlackFile *SlackFileObject `json:"slack_file,omitempty"`
+		AltText   string           `json:"alt_text"`
 	}
 
 	// TextObject see https://api.slack.com/reference/block-kit/composition-objects#text
@@ -126,6 +229,12 @@ type (
 		URL         string      `json:"url,omitempty"`
 	}
 
+	// SlackFileObject see https://api.slack.com/reference/block-kit/composition-objects#slack_file
+	SlackFileObject struct {
+		URL string `json:"url,omitempty"`
+		ID  string `json:"id,omitempty"`
+	}
+
 	// ConfirmObject see https://api.slack.com/reference/block-kit/composition-objects#confirm
 	ConfirmObject struct {
 		Title   *TextObject `json:"title"`
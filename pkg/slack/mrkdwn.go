@@ -0,0 +1,201 @@
+package slack
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// see https://api.slack.com/reference/surfaces/formatting
+
+const (
+	// DateFormatNumeric e.g. 2014-02-18
+	DateFormatNumeric = "{date_num}"
+	// DateFormatShort e.g. Feb 18, 2014
+	DateFormatShort = "{date_short}"
+	// DateFormatLong e.g. Tuesday, February 18th, 2014
+	DateFormatLong = "{date_long}"
+	// DateFormatPretty e.g. yesterday, or Feb 18, 2014
+	DateFormatPretty = "{date_short_pretty}"
+	// DateFormatTime e.g. 6:39 AM
+	DateFormatTime = "{time}"
+)
+
+type (
+	// MessageEntities are the mentions and links found in a message text
+	MessageEntities struct {
+		Users      []MessageMention
+		Channels   []MessageMention
+		Usergroups []MessageMention
+		Special    []string // here, channel, everyone
+		Links      []MessageLink
+	}
+
+	// MessageMention is a mention of a user, channel or usergroup, e.g. <@U123|name>
+	MessageMention struct {
+		ID    string
+		Label string
+	}
+
+	// MessageLink is a link with an optional label, e.g. <https://example.com|Example>
+	MessageLink struct {
+		URL   string
+		Label string
+	}
+)
+
+var mrkdwnEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+var mrkdwnUnescaper = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">")
+var entityPattern = regexp.MustCompile(`<([^<>]+)>`)
+
+// Escape escapes the control characters &, < and >, so that user provided text can not
+// break the formatting or trigger mentions like <!channel>
+func Escape(text string) string {
+	return mrkdwnEscaper.Replace(text)
+}
+
+// Unescape reverts Escape
+func Unescape(text string) string {
+	return mrkdwnUnescaper.Replace(text)
+}
+
+// MentionUser mentions a user, e.g. <@U123>
+func MentionUser(userID string) string {
+	return "<@" + userID + ">"
+}
+
+// MentionChannel links to a channel, e.g. <#C123>
+func MentionChannel(channelID string) string {
+	return "<#" + channelID + ">"
+}
+
+// MentionUsergroup mentions a usergroup, e.g. <!subteam^S123>
+func MentionUsergroup(usergroupID string) string {
+	return "<!subteam^" + usergroupID + ">"
+}
+
+// MentionHere notifies all active members of a channel
+func MentionHere() string {
+	return "<!here>"
+}
+
+// MentionChannelMembers notifies all members of a channel
+func MentionChannelMembers() string {
+	return "<!channel>"
+}
+
+// MentionEveryone notifies every member of the workspace, in #general only
+func MentionEveryone() string {
+	return "<!everyone>"
+}
+
+// Link creates a link with an optional label
+func Link(url, label string) string {
+	url = strings.NewReplacer("<", "%3C", ">", "%3E", "|", "%7C").Replace(url)
+	if label == "" {
+		return "<" + url + ">"
+	}
+	return "<" + url + "|" + Escape(label) + ">"
+}
+
+// Date formats a UNIX timestamp in the reader's timezone, e.g. Date(ts, "{date_short} at {time}", "", "Feb 18, 2014")
+// The link is optional, the fallback is shown if the client can not format the date.
+func Date(ts int64, format, link, fallback string) string {
+	s := "<!date^" + strconv.FormatInt(ts, 10) + "^" + format
+	if link != "" {
+		s += "^" + link
+	}
+	return s + "|" + Escape(fallback) + ">"
+}
+
+// Bold formats text as bold
+func Bold(text string) string {
+	return wrap(text, "*")
+}
+
+// Italic formats text as italic
+func Italic(text string) string {
+	return wrap(text, "_")
+}
+
+// Strike formats text as strikethrough
+func Strike(text string) string {
+	return wrap(text, "~")
+}
+
+// Code formats text as inline code
+func Code(text string) string {
+	return wrap(text, "`")
+}
+
+// CodeBlock formats text as multi-line code block
+func CodeBlock(text string) string {
+	return "```\n" + strings.TrimSuffix(text, "\n") + "\n```"
+}
+
+// Quote formats text as block quote, every line is quoted
+func Quote(text string) string {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for i := range lines {
+		lines[i] = ">" + lines[i]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// BulletList formats items as bullet list. mrkdwn has no list syntax, so a bullet character is used.
+func BulletList(items ...string) string {
+	lines := make([]string, len(items))
+	for i := range items {
+		lines[i] = "• " + items[i]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NumberedList formats items as numbered list
+func NumberedList(items ...string) string {
+	lines := make([]string, len(items))
+	for i := range items {
+		lines[i] = fmt.Sprintf("%d. %s", i+1, items[i])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseEntities extracts mentions and links from a message text
+func ParseEntities(text string) *MessageEntities {
+	entities := MessageEntities{}
+
+	for _, m := range entityPattern.FindAllStringSubmatch(text, -1) {
+		content, label := m[1], ""
+		if i := strings.Index(content, "|"); i >= 0 {
+			content, label = content[:i], Unescape(content[i+1:])
+		}
+
+		switch {
+		case strings.HasPrefix(content, "@"):
+			entities.Users = append(entities.Users, MessageMention{ID: content[1:], Label: label})
+		case strings.HasPrefix(content, "#"):
+			entities.Channels = append(entities.Channels, MessageMention{ID: content[1:], Label: label})
+		case strings.HasPrefix(content, "!subteam^"):
+			entities.Usergroups = append(entities.Usergroups, MessageMention{ID: strings.TrimPrefix(content, "!subteam^"), Label: label})
+		case content == "!here" || content == "!channel" || content == "!everyone":
+			entities.Special = append(entities.Special, content[1:])
+		case strings.HasPrefix(content, "!"):
+			// dates and other commands
+		default:
+			entities.Links = append(entities.Links, MessageLink{URL: Unescape(content), Label: label})
+		}
+	}
+
+	return &entities
+}
+
+// wrap adds a formatting character around text, keeping leading and trailing whitespace outside
+func wrap(text, c string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	i := strings.Index(text, trimmed)
+	return text[:i] + c + trimmed + c + text[i+len(trimmed):]
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 
+	"cloud.google.com/go/datastore"
 	"golang.org/x/net/context"
 
 	"github.com/gin-gonic/gin"
@@ -19,16 +20,39 @@ import (
 type (
 	// OAuthResponse is used to give a simple reponse to the user as feedback to a custom action reuqest
 	OAuthResponse struct {
-		OK              bool            `json:"ok,omitempty"`
-		AccessToken     string          `json:"access_token,omitempty"`
-		TokenType       string          `json:"token_type,omitempty"`
-		Scope           string          `json:"scope,omitempty"`
-		AppID           string          `json:"app_id,omitempty"`
-		BotUserID       string          `json:"bot_user_id,omitempty"`
-		IncomingWebhook *WebhookElement `json:"incoming_webhook,omitempty"`
+		OK                  bool                `json:"ok,omitempty"`
+		Error               string              `json:"error,omitempty"`
+		AccessToken         string              `json:"access_token,omitempty"`
+		TokenType           string              `json:"token_type,omitempty"`
+		Scope               string              `json:"scope,omitempty"`
+		AppID               string              `json:"app_id,omitempty"`
+		BotUserID           string              `json:"bot_user_id,omitempty"`
+		Team                *InstallationEntity `json:"team,omitempty"`
+		Enterprise          *InstallationEntity `json:"enterprise,omitempty"`
+		IsEnterpriseInstall bool                `json:"is_enterprise_install,omitempty"`
+		IncomingWebhook     *WebhookElement     `json:"incoming_webhook,omitempty"`
+	}
+
+	// UninstallFunc is a callback for app-specific cleanup once an installation was removed
+	UninstallFunc func(ctx context.Context, clientID string) error
+
+	// RevokeResponse see https://api.slack.com/methods/auth.revoke
+	RevokeResponse struct {
+		OK      bool   `json:"ok"`
+		Revoked bool   `json:"revoked,omitempty"`
+		Error   string `json:"error,omitempty"`
+	}
+
+	// InstallationEntity identifies the workspace or organization an app was installed into
+	InstallationEntity struct {
+		ID   string `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
 	}
 )
 
+// uninstall callback
+var uninstallHandler UninstallFunc
+
 // OAuthEndpoint handles the callback from Slack with the temporary access code
 // and exchanges it with the real auth token. See https://api.slack.com/docs/oauth
 func OAuthEndpoint(c *gin.Context) {
@@ -41,45 +65,50 @@ func OAuthEndpoint(c *gin.Context) {
 	// FIXME: secure the request by using a state
 	// state := c.Query("state")
 
+	// the user declined the installation or something else went wrong on Slack's side
+	if e := c.Query("error"); e != "" {
+		if e == OAuthErrorAccessDenied {
+			c.Redirect(http.StatusTemporaryRedirect, oauthCancelURL())
+			return
+		}
+		platform.ReportError(fmt.Errorf("oauth error: %s", e))
+		c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorSlack))
+		return
+	}
+
 	if code != "" {
 		// exchange the temporary code with a real auth token
 		resp, err := getOAuthToken(ctx, code)
 
 		if err != nil {
 			platform.ReportError(err)
-			c.Redirect(http.StatusTemporaryRedirect, "/error")
+			c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorTokenExchange))
 			return
 		}
 
-		// get team info
-		var teamInfo TeamInfo
-		err = Get(ctx, resp.AccessToken, "team.info", "", &teamInfo)
-		if err != nil {
-			platform.ReportError(err)
-			c.Redirect(http.StatusTemporaryRedirect, "/error")
+		if resp.OK == false {
+			platform.ReportError(fmt.Errorf("error: %s", resp.Error))
+			c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorTokenExchange))
 			return
 		}
 
-		if teamInfo.OK == false {
-			platform.ReportError(fmt.Errorf("error: %s", teamInfo.Error))
-			c.Redirect(http.StatusTemporaryRedirect, "/error")
+		clientID, name, err := installationOwner(ctx, resp)
+		if err != nil {
+			platform.ReportError(err)
+			c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorTeamInfo))
 			return
 		}
 
-		err = UpdateAuthorization(ctx, teamInfo.Team.ID, teamInfo.Team.Name, resp.AccessToken, resp.TokenType, resp.Scope, resp.AppID, resp.BotUserID)
+		err = UpdateAuthorization(ctx, clientID, name, resp.AccessToken, resp.TokenType, resp.Scope, resp.AppID, resp.BotUserID)
 		if err != nil {
 			platform.ReportError(err)
-			c.Redirect(http.StatusTemporaryRedirect, "/error")
+			c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorStorage))
 			return
 		}
 	}
 
 	// back to the sign-up process on the main website
-	if redirectURI == "" {
-		c.Redirect(http.StatusTemporaryRedirect, "/")
-	} else {
-		c.Redirect(http.StatusTemporaryRedirect, redirectURI)
-	}
+	c.Redirect(http.StatusTemporaryRedirect, oauthSuccessURL(redirectURI))
 }
 
 // getOAuthToken exchanges a temporary OAuth verifier code for an access token
@@ -111,21 +140,74 @@ func getOAuthToken(ctx context.Context, code string) (*OAuthResponse, error) {
 	return &response, err
 }
 
+// installationOwner returns the ID and name the installation is stored under. Org-wide
+// installs on Enterprise Grid are keyed by the enterprise ID, all others by the team ID.
+func installationOwner(ctx context.Context, resp *OAuthResponse) (string, string, error) {
+	if resp.IsEnterpriseInstall {
+		if resp.Enterprise == nil || resp.Enterprise.ID == "" {
+			return "", "", fmt.Errorf("enterprise install without enterprise ID")
+		}
+		return resp.Enterprise.ID, resp.Enterprise.Name, nil
+	}
+
+	// get team info
+	var teamInfo TeamInfo
+	err := Get(ctx, resp.AccessToken, "team.info", "", &teamInfo)
+	if err != nil {
+		return "", "", err
+	}
+
+	if teamInfo.OK == false {
+		return "", "", fmt.Errorf("error: %s", teamInfo.Error)
+	}
+
+	return teamInfo.Team.ID, teamInfo.Team.Name, nil
+}
+
+// GetToken returns the access token for a team. If the team has no installation of its own,
+// the token of an org-wide installation of its Enterprise Grid organization is used instead.
+func GetToken(ctx context.Context, teamID, enterpriseID string) (string, error) {
+	if teamID != "" {
+		token, err := getInstallationToken(ctx, teamID)
+		if err == nil {
+			return token, nil
+		}
+		if enterpriseID == "" {
+			return "", err
+		}
+	}
+	if enterpriseID == "" {
+		return "", fmt.Errorf("no team or enterprise ID")
+	}
+	return getInstallationToken(ctx, enterpriseID)
+}
+
 // UpdateAuthorization updates the authorization, or creates a new one.
+// The clientID is the team ID, or the enterprise ID for org-wide installations.
+// The token is encrypted if a KeyProvider is configured, auth.GetToken then returns the
+// encrypted token. Use GetToken of this package instead.
 func UpdateAuthorization(ctx context.Context, clientID, teamName, token, tokenType, scope, appID, botID string) error {
+	token, err := EncryptToken(clientID, token)
+	if err != nil {
+		return err
+	}
 
 	// find the authorization first
 	a, err := auth.GetAuthorization(ctx, clientID, auth.AuthTypeSlack)
 
 	now := util.Timestamp()
 	if err == nil {
+		a.Name = teamName
 		a.Token = token
+		a.TokenType = tokenType
+		a.UserID = botID
 		a.Scope = scope
+		a.Expires = 0 // the app might have been re-installed after it was revoked
 		a.Updated = now
 	} else {
 		a = &auth.Authorization{
-			ClientID:  clientID, // TeamID
-			Name:      teamName, // Team name
+			ClientID:  clientID, // TeamID or EnterpriseID
+			Name:      teamName, // Team or enterprise name
 			Token:     token,
 			TokenType: tokenType,
 			UserID:    botID,
@@ -140,3 +222,88 @@ func UpdateAuthorization(ctx context.Context, clientID, teamName, token, tokenTy
 
 	return auth.CreateAuthorization(ctx, a)
 }
+
+// RegisterUninstallHandler adds a handler that is called after an installation was removed
+func RegisterUninstallHandler(h UninstallFunc) {
+	uninstallHandler = h
+}
+
+// RevokeToken revokes an access token by calling auth.revoke. See https://api.slack.com/methods/auth.revoke
+func RevokeToken(ctx context.Context, token string) error {
+	var resp RevokeResponse
+	err := Get(ctx, token, "auth.revoke", "", &resp)
+	if err != nil {
+		return err
+	}
+	if resp.OK == false {
+		return fmt.Errorf("error: %s", resp.Error)
+	}
+	return nil
+}
+
+// Disconnect revokes the access token with Slack and marks the installation as revoked.
+// Use this to implement a "disconnect" button in the app.
+func Disconnect(ctx context.Context, clientID string) error {
+	a, err := findAuthorization(ctx, clientID)
+	if err != nil {
+		return err
+	}
+
+	token, err := DecryptToken(clientID, a.Token)
+	if err == nil {
+		err = RevokeToken(ctx, token)
+	}
+	if err != nil {
+		platform.ReportError(err) // remove the installation anyways
+	}
+
+	return RevokeAuthorization(ctx, clientID)
+}
+
+// RevokeAuthorization removes the token of an installation and calls the uninstall handler, if any.
+// The authorization is kept without a token and marked as expired. It is updated through the
+// auth package, which also invalidates its cache.
+func RevokeAuthorization(ctx context.Context, clientID string) error {
+	if clientID == "" {
+		return fmt.Errorf("no client ID")
+	}
+
+	a, err := auth.GetAuthorization(ctx, clientID, auth.AuthTypeSlack)
+	if err != nil && err != datastore.ErrNoSuchEntity {
+		return err
+	}
+
+	if a != nil && isInstalled(a) {
+		now := util.Timestamp()
+		a.Token = ""
+		a.Scope = ""
+		a.Expires = now
+		a.Updated = now
+
+		if err := auth.CreateAuthorization(ctx, a); err != nil {
+			return err
+		}
+	}
+
+	if uninstallHandler != nil {
+		return uninstallHandler(ctx, clientID)
+	}
+	return nil
+}
+
+// findAuthorization returns the authorization of an installation, revoked installations are not found
+func findAuthorization(ctx context.Context, clientID string) (*auth.Authorization, error) {
+	a, err := auth.GetAuthorization(ctx, clientID, auth.AuthTypeSlack)
+	if err != nil {
+		return nil, err
+	}
+	if !isInstalled(a) {
+		return nil, fmt.Errorf("installation '%s' was revoked", clientID)
+	}
+	return a, nil
+}
+
+// isInstalled returns false for authorizations revoked by RevokeAuthorization
+func isInstalled(a *auth.Authorization) bool {
+	return a.Token != "" && a.IsValid()
+}
@@ -0,0 +1,459 @@
+package slack
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+	"google.golang.org/appengine"
+
+	"github.com/txsvc/commons/pkg/util"
+	"github.com/txsvc/platform/pkg/platform"
+)
+
+// see https://api.slack.com/authentication/sign-in-with-slack
+
+const (
+	// SlackOpenIDAuthorizeEndpoint is the URI users are sent to in order to sign in with Slack
+	SlackOpenIDAuthorizeEndpoint string = "https://slack.com/openid/connect/authorize"
+	// SlackOpenIDKeysEndpoint is the URI of Slack's JSON Web Key Set
+	SlackOpenIDKeysEndpoint string = "https://slack.com/openid/connect/keys"
+	// SlackOpenIDIssuer is the issuer of Slack ID tokens
+	SlackOpenIDIssuer string = "https://slack.com"
+	// SlackOpenIDRedirectURI is the callback URI registered for Sign in with Slack
+	SlackOpenIDRedirectURI string = "SLACK_OPENID_REDIRECT_URI"
+
+	// signInExpiration is the time in seconds a sign-in attempt remains valid
+	signInExpiration = 600
+	// signInStateCookie binds a sign-in attempt to the browser that started it
+	signInStateCookie = "slack_sign_in_state"
+	// openIDKeyRefreshInterval limits how often the key set is fetched again for unknown key IDs
+	openIDKeyRefreshInterval = time.Minute
+)
+
+type (
+	// SignInFunc is a callback for establishing a session once a user signed in with Slack
+	SignInFunc func(*gin.Context, *OpenIDClaims, *OpenIDTokenResponse) error
+
+	// OpenIDTokenResponse see https://api.slack.com/methods/openid.connect.token
+	OpenIDTokenResponse struct {
+		OK           bool   `json:"ok"`
+		Error        string `json:"error,omitempty"`
+		AccessToken  string `json:"access_token,omitempty"`
+		TokenType    string `json:"token_type,omitempty"`
+		IDToken      string `json:"id_token,omitempty"`
+		RefreshToken string `json:"refresh_token,omitempty"`
+		ExpiresIn    int64  `json:"expires_in,omitempty"`
+	}
+
+	// OpenIDClaims are the claims of a Slack ID token
+	OpenIDClaims struct {
+		Issuer        string      `json:"iss"`
+		Subject       string      `json:"sub"`
+		Audience      interface{} `json:"aud"` // a string or an array of strings
+		Expires       int64       `json:"exp"`
+		IssuedAt      int64       `json:"iat"`
+		AuthTime      int64       `json:"auth_time,omitempty"`
+		Nonce         string      `json:"nonce,omitempty"`
+		UserID        string      `json:"https://slack.com/user_id,omitempty"`
+		TeamID        string      `json:"https://slack.com/team_id,omitempty"`
+		Email         string      `json:"email,omitempty"`
+		EmailVerified bool        `json:"email_verified,omitempty"`
+		Name          string      `json:"name,omitempty"`
+		Picture       string      `json:"picture,omitempty"`
+		GivenName     string      `json:"given_name,omitempty"`
+		FamilyName    string      `json:"family_name,omitempty"`
+		Locale        string      `json:"locale,omitempty"`
+		TeamName      string      `json:"https://slack.com/team_name,omitempty"`
+		TeamDomain    string      `json:"https://slack.com/team_domain,omitempty"`
+	}
+
+	// OpenIDUserInfo see https://api.slack.com/methods/openid.connect.userInfo
+	OpenIDUserInfo struct {
+		OK            bool   `json:"ok"`
+		Error         string `json:"error,omitempty"`
+		Subject       string `json:"sub,omitempty"`
+		UserID        string `json:"https://slack.com/user_id,omitempty"`
+		TeamID        string `json:"https://slack.com/team_id,omitempty"`
+		Email         string `json:"email,omitempty"`
+		EmailVerified bool   `json:"email_verified,omitempty"`
+		Name          string `json:"name,omitempty"`
+		Picture       string `json:"picture,omitempty"`
+		GivenName     string `json:"given_name,omitempty"`
+		FamilyName    string `json:"family_name,omitempty"`
+		Locale        string `json:"locale,omitempty"`
+		TeamName      string `json:"https://slack.com/team_name,omitempty"`
+		TeamDomain    string `json:"https://slack.com/team_domain,omitempty"`
+	}
+
+	// JSONWebKeySet is a set of public keys used to verify ID tokens
+	JSONWebKeySet struct {
+		Keys []JSONWebKey `json:"keys"`
+	}
+
+	// JSONWebKey is a RSA public key, see RFC 7517
+	JSONWebKey struct {
+		KeyType   string `json:"kty"`
+		KeyID     string `json:"kid"`
+		Use       string `json:"use,omitempty"`
+		Algorithm string `json:"alg,omitempty"`
+		N         string `json:"n"`
+		E         string `json:"e"`
+	}
+
+	jwtHeader struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+)
+
+// sign-in callback and the key set used to verify ID tokens
+var signInHandler SignInFunc
+var openIDKeySet *JSONWebKeySet
+var openIDKeySetFetched time.Time
+var openIDKeySetStatic bool
+var openIDKeySetMutex sync.Mutex
+
+// RegisterSignInHandler adds the handler that is called after a user signed in with Slack
+func RegisterSignInHandler(h SignInFunc) {
+	signInHandler = h
+}
+
+// SetOpenIDKeySet replaces the key set fetched from Slack, e.g. with a local key set for tests.
+// The key set is not refreshed from Slack, unless it is reset with nil.
+func SetOpenIDKeySet(ks *JSONWebKeySet) {
+	openIDKeySetMutex.Lock()
+	defer openIDKeySetMutex.Unlock()
+
+	openIDKeySet = ks
+	openIDKeySetStatic = ks != nil
+	openIDKeySetFetched = time.Time{}
+}
+
+// SignInEndpoint starts the Sign in with Slack flow by redirecting the user to Slack
+func SignInEndpoint(c *gin.Context) {
+	ctx := appengine.NewContext(c.Request)
+
+	state, err := util.SimpleUUID()
+	if err != nil {
+		platform.ReportError(err)
+		c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorSignIn))
+		return
+	}
+	nonce, err := util.SimpleUUID()
+	if err != nil {
+		platform.ReportError(err)
+		c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorSignIn))
+		return
+	}
+
+	// remember the nonce, the state is used to find it again
+	err = setExpiringKV(ctx, signInKey(state), nonce, signInExpiration)
+	if err != nil {
+		platform.ReportError(err)
+		c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorStorage))
+		return
+	}
+
+	setSignInCookie(c, state, signInExpiration)
+	c.Redirect(http.StatusTemporaryRedirect, SignInURL(state, nonce))
+}
+
+// SignInCallbackEndpoint handles the callback from Slack, verifies the ID token and
+// hands the claims to the sign-in handler. Users are redirected to the same success,
+// failure and cancel URLs as after an installation. The state must match the cookie set
+// by SignInEndpoint and can only be used once.
+func SignInCallbackEndpoint(c *gin.Context) {
+	ctx := appengine.NewContext(c.Request)
+
+	// the user declined the sign-in or something else went wrong on Slack's side
+	if e := c.Query("error"); e != "" {
+		if e == OAuthErrorAccessDenied {
+			c.Redirect(http.StatusTemporaryRedirect, oauthCancelURL())
+			return
+		}
+		platform.ReportError(fmt.Errorf("sign-in error: %s", e))
+		c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorSlack))
+		return
+	}
+
+	code := c.Query("code")
+	state := c.Query("state")
+	if code == "" || state == "" {
+		c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorInvalidState))
+		return
+	}
+
+	// the sign-in must have been started in this browser, otherwise someone else's sign-in
+	// could be completed in the user's session
+	ok := signInStateMatches(c.Request, state)
+	setSignInCookie(c, "", -1)
+	if !ok {
+		platform.ReportError(fmt.Errorf("sign-in state does not match the browser"))
+		c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorInvalidState))
+		return
+	}
+
+	nonce, ok := consumeExpiringKV(ctx, signInKey(state))
+	if !ok || nonce == "" {
+		platform.ReportError(fmt.Errorf("unknown, expired or already used sign-in state"))
+		c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorInvalidState))
+		return
+	}
+
+	resp, err := getOpenIDToken(ctx, code)
+	if err != nil {
+		platform.ReportError(err)
+		c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorTokenExchange))
+		return
+	}
+
+	claims, err := VerifyIDToken(ctx, resp.IDToken, nonce)
+	if err != nil {
+		platform.ReportError(err)
+		c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorInvalidIDToken))
+		return
+	}
+
+	if signInHandler != nil {
+		err = signInHandler(c, claims, resp)
+		if err != nil {
+			platform.ReportError(err)
+			c.Redirect(http.StatusTemporaryRedirect, oauthFailureURL(OAuthErrorSignIn))
+			return
+		}
+		if c.Writer.Written() {
+			return // the handler already responded
+		}
+	}
+
+	c.Redirect(http.StatusTemporaryRedirect, envURL(SlackOAuthSuccessURL, defaultSuccessURL))
+}
+
+// setSignInCookie stores the state in the browser, a negative maxAge deletes the cookie
+func setSignInCookie(c *gin.Context, state string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     signInStateCookie,
+		Value:    state,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteLaxMode, // sent with the redirect back from Slack
+	})
+}
+
+// signInStateMatches compares the state of the callback with the cookie of the browser
+func signInStateMatches(r *http.Request, state string) bool {
+	cookie, err := r.Cookie(signInStateCookie)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+	return hmac.Equal([]byte(cookie.Value), []byte(state))
+}
+
+// SignInURL returns the URL users are sent to in order to sign in with Slack
+func SignInURL(state, nonce string) string {
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("scope", "openid profile email")
+	q.Set("client_id", os.Getenv(SlackClientID))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	if redirectURI := os.Getenv(SlackOpenIDRedirectURI); redirectURI != "" {
+		q.Set("redirect_uri", redirectURI)
+	}
+	return SlackOpenIDAuthorizeEndpoint + "?" + q.Encode()
+}
+
+// GetOpenIDUserInfo returns the identity of the user the access token was issued to
+func GetOpenIDUserInfo(ctx context.Context, token string) (*OpenIDUserInfo, error) {
+	var info OpenIDUserInfo
+	err := Get(ctx, token, "openid.connect.userInfo", "", &info)
+	if err != nil {
+		return nil, err
+	}
+	if info.OK == false {
+		return nil, fmt.Errorf("error: %s", info.Error)
+	}
+	return &info, nil
+}
+
+// VerifyIDToken verifies the signature and claims of a Slack ID token and returns its claims
+func VerifyIDToken(ctx context.Context, token, nonce string) (*OpenIDClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed id token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Algorithm != "RS256" {
+		return nil, fmt.Errorf("unsupported signing algorithm '%s'", header.Algorithm)
+	}
+
+	key, err := lookupOpenIDKey(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("invalid id token signature")
+	}
+
+	var claims OpenIDClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	if claims.Issuer != SlackOpenIDIssuer {
+		return nil, fmt.Errorf("invalid issuer '%s'", claims.Issuer)
+	}
+	if !claims.hasAudience(os.Getenv(SlackClientID)) {
+		return nil, fmt.Errorf("invalid audience")
+	}
+	if claims.Expires < time.Now().Unix() {
+		return nil, fmt.Errorf("id token expired")
+	}
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("invalid nonce")
+	}
+
+	return &claims, nil
+}
+
+// PublicKey returns the RSA public key
+func (k *JSONWebKey) PublicKey() (*rsa.PublicKey, error) {
+	if k.KeyType != "RSA" {
+		return nil, fmt.Errorf("unsupported key type '%s'", k.KeyType)
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+func (c *OpenIDClaims) hasAudience(clientID string) bool {
+	switch aud := c.Audience.(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getOpenIDToken exchanges a temporary code for an access and ID token
+func getOpenIDToken(ctx context.Context, code string) (*OpenIDTokenResponse, error) {
+	form := url.Values{}
+	form.Set("client_id", os.Getenv(SlackClientID))
+	form.Set("client_secret", os.Getenv(SlackClientSecret))
+	form.Set("code", code)
+	if redirectURI := os.Getenv(SlackOpenIDRedirectURI); redirectURI != "" {
+		form.Set("redirect_uri", redirectURI)
+	}
+
+	resp, err := http.PostForm(SlackEndpoint+"openid.connect.token", form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// unmarshal the response
+	var response OpenIDTokenResponse
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		return nil, err
+	}
+	if response.OK == false {
+		return nil, fmt.Errorf("error: %s", response.Error)
+	}
+
+	return &response, nil
+}
+
+// lookupOpenIDKey finds the key with the given ID. Slack's key set is fetched if needed, and
+// again if the key is unknown, e.g. after Slack rotated its keys, but at most once a minute.
+func lookupOpenIDKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	openIDKeySetMutex.Lock()
+	defer openIDKeySetMutex.Unlock()
+
+	if k := openIDKeySet.find(kid); k != nil {
+		return k.PublicKey()
+	}
+	if openIDKeySetStatic || time.Since(openIDKeySetFetched) < openIDKeyRefreshInterval {
+		return nil, fmt.Errorf("unknown key '%s'", kid)
+	}
+
+	openIDKeySetFetched = time.Now()
+
+	var ks JSONWebKeySet
+	if err := util.GetJSON(SlackOpenIDKeysEndpoint, &ks); err != nil {
+		return nil, err
+	}
+	openIDKeySet = &ks
+
+	if k := openIDKeySet.find(kid); k != nil {
+		return k.PublicKey()
+	}
+	return nil, fmt.Errorf("unknown key '%s'", kid)
+}
+
+// find returns the key with the given ID, or nil
+func (ks *JSONWebKeySet) find(kid string) *JSONWebKey {
+	if ks == nil {
+		return nil
+	}
+	for i := range ks.Keys {
+		if ks.Keys[i].KeyID == kid {
+			return &ks.Keys[i]
+		}
+	}
+	return nil
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func signInKey(state string) string {
+	return "openid." + state
+}
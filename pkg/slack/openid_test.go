@@ -0,0 +1,144 @@
+package slack
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func signIDToken(t *testing.T, key *rsa.PrivateKey, header, claims map[string]interface{}) string {
+	h, _ := json.Marshal(header)
+	c, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func jsonWebKey(kid string, key *rsa.PublicKey) JSONWebKey {
+	return JSONWebKey{
+		KeyType: "RSA",
+		KeyID:   kid,
+		N:       base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv(SlackClientID, "client")
+	defer os.Unsetenv(SlackClientID)
+
+	SetOpenIDKeySet(&JSONWebKeySet{Keys: []JSONWebKey{jsonWebKey("k1", &key.PublicKey)}})
+	defer SetOpenIDKeySet(nil)
+
+	header := map[string]interface{}{"alg": "RS256", "kid": "k1"}
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss":                       SlackOpenIDIssuer,
+			"sub":                       "U1",
+			"aud":                       "client",
+			"exp":                       time.Now().Add(time.Hour).Unix(),
+			"iat":                       time.Now().Unix(),
+			"nonce":                     "n1",
+			"https://slack.com/user_id": "U1",
+			"https://slack.com/team_id": "T1",
+		}
+	}
+	with := func(k string, v interface{}) map[string]interface{} {
+		c := valid()
+		c[k] = v
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"valid", signIDToken(t, key, header, valid()), false},
+		{"audience list", signIDToken(t, key, header, with("aud", []string{"other", "client"})), false},
+		{"wrong audience", signIDToken(t, key, header, with("aud", "other")), true},
+		{"wrong issuer", signIDToken(t, key, header, with("iss", "https://evil.com")), true},
+		{"expired", signIDToken(t, key, header, with("exp", time.Now().Add(-time.Minute).Unix())), true},
+		{"wrong nonce", signIDToken(t, key, header, with("nonce", "n2")), true},
+		{"wrong key", signIDToken(t, other, header, valid()), true},
+		{"unknown key", signIDToken(t, key, map[string]interface{}{"alg": "RS256", "kid": "k2"}, valid()), true},
+		{"unsupported algorithm", signIDToken(t, key, map[string]interface{}{"alg": "HS256", "kid": "k1"}, valid()), true},
+		{"malformed", "a.b", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := VerifyIDToken(nil, tt.token, "n1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyIDToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (claims.UserID != "U1" || claims.TeamID != "T1") {
+				t.Errorf("VerifyIDToken() = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestSignInStateMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		cookie string
+		state  string
+		want   bool
+	}{
+		{"same browser", "s1", "s1", true},
+		{"other state", "s1", "s2", false},
+		{"no cookie", "", "s1", false},
+		{"empty state", "s1", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/signin/callback?state="+tt.state, nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: signInStateCookie, Value: tt.cookie})
+			}
+			if got := signInStateMatches(r, tt.state); got != tt.want {
+				t.Errorf("signInStateMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetSignInCookie(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "https://example.com/signin", nil)
+
+	setSignInCookie(c, "s1", signInExpiration)
+
+	cookie := w.Result().Cookies()[0]
+	if cookie.Name != signInStateCookie || cookie.Value != "s1" || !cookie.HttpOnly || !cookie.Secure || cookie.SameSite != http.SameSiteLaxMode || cookie.MaxAge != signInExpiration {
+		t.Errorf("cookie = %+v", cookie)
+	}
+}
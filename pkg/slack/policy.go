@@ -0,0 +1,236 @@
+package slack
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+	"google.golang.org/appengine"
+
+	"github.com/txsvc/platform/pkg/platform"
+)
+
+// policyCacheExpiration is the time in seconds memberships and roles are cached
+const policyCacheExpiration = 300
+
+type (
+	// Policy restricts who can use a command or action, and where. Users, Usergroups, Admins
+	// and Owners are alternatives, the user must match one of them if any is set. The channel
+	// and team restrictions apply in addition. Channel restrictions are skipped for interactions
+	// without a channel, e.g. view submissions.
+	Policy struct {
+		Users        []string // user IDs
+		Usergroups   []string // usergroup IDs, resolved with usergroups.users.list
+		Admins       bool     // workspace admins and owners, resolved with users.info
+		Owners       bool     // workspace owners
+		Channels     []string // allowed channels, all if empty
+		DenyChannels []string
+		Teams        []string // allowed teams, all if empty
+		Message      string   // replaces the standard denial message
+	}
+
+	// UserRole is the role of a user in a workspace
+	UserRole struct {
+		IsAdmin        bool
+		IsOwner        bool
+		IsPrimaryOwner bool
+	}
+
+	// PolicyResolver looks up usergroup memberships and user roles
+	PolicyResolver interface {
+		UsergroupMembers(ctx context.Context, i *Interaction, usergroupID string) ([]string, error)
+		UserRole(ctx context.Context, i *Interaction, userID string) (*UserRole, error)
+	}
+
+	// webPolicyResolver queries the Web API
+	webPolicyResolver struct{}
+
+	// cachedPolicyResolver caches the results of another resolver
+	cachedPolicyResolver struct {
+		resolver PolicyResolver
+		store    expiringStore
+	}
+)
+
+var policyResolver PolicyResolver = &cachedPolicyResolver{resolver: &webPolicyResolver{}, store: kvStore{}}
+
+// SetPolicyResolver replaces the resolver used by Authorize
+func SetPolicyResolver(r PolicyResolver) {
+	policyResolver = r
+}
+
+// Authorize returns middleware that enforces a policy. Users who are not allowed get an
+// ephemeral denial message, the handler is not called.
+func Authorize(p *Policy) Middleware {
+	return func(next InteractionFunc) InteractionFunc {
+		return func(c *gin.Context, i *Interaction) (*SectionBlocks, error) {
+			ctx := appengine.NewContext(c.Request)
+
+			allowed, err := p.Allows(ctx, i)
+			if err != nil {
+				platform.ReportError(err)
+			}
+			if !allowed {
+				return p.denial(i), nil
+			}
+			return next(c, i)
+		}
+	}
+}
+
+// Allows checks the policy. Lookup errors deny access.
+func (p *Policy) Allows(ctx context.Context, i *Interaction) (bool, error) {
+	if len(p.Teams) > 0 && !contains(p.Teams, i.TeamID()) {
+		return false, nil
+	}
+	if channel := i.ChannelID(); channel != "" && len(p.Channels) > 0 && !contains(p.Channels, channel) {
+		return false, nil
+	}
+	if contains(p.DenyChannels, i.ChannelID()) {
+		return false, nil
+	}
+
+	if len(p.Users) == 0 && len(p.Usergroups) == 0 && !p.Admins && !p.Owners {
+		return true, nil
+	}
+
+	user := i.UserID()
+	if contains(p.Users, user) {
+		return true, nil
+	}
+
+	for _, group := range p.Usergroups {
+		members, err := policyResolver.UsergroupMembers(ctx, i, group)
+		if err != nil {
+			return false, err
+		}
+		if contains(members, user) {
+			return true, nil
+		}
+	}
+
+	if p.Admins || p.Owners {
+		role, err := policyResolver.UserRole(ctx, i, user)
+		if err != nil {
+			return false, err
+		}
+		if role.IsOwner || role.IsPrimaryOwner || p.Admins && role.IsAdmin {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// denial is the standard ephemeral denial message
+func (p *Policy) denial(i *Interaction) *SectionBlocks {
+	text := p.Message
+	if text == "" {
+		what := "this"
+		if i.Type == InteractionSlashCommand {
+			what = Code(Escape(i.Name))
+		}
+		text = fmt.Sprintf(":no_entry: Sorry, you are not allowed to use %s here.", what)
+	}
+	return NewEphemeralResponse(text, &SectionBlock{Text: TextObject{Type: "mrkdwn", Text: text}})
+}
+
+// UsergroupMembers implements PolicyResolver
+func (r *cachedPolicyResolver) UsergroupMembers(ctx context.Context, i *Interaction, usergroupID string) ([]string, error) {
+	key := "policy.usergroup." + i.ClientID() + "." + usergroupID
+	if v, ok := r.store.Get(ctx, key); ok {
+		if v == "" {
+			return nil, nil
+		}
+		return strings.Split(v, ","), nil
+	}
+
+	members, err := r.resolver.UsergroupMembers(ctx, i, usergroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store.Set(ctx, key, strings.Join(members, ","), policyCacheExpiration)
+	return members, nil
+}
+
+// UserRole implements PolicyResolver
+func (r *cachedPolicyResolver) UserRole(ctx context.Context, i *Interaction, userID string) (*UserRole, error) {
+	key := "policy.role." + i.ClientID() + "." + userID
+	if v, ok := r.store.Get(ctx, key); ok {
+		return &UserRole{
+			IsAdmin:        strings.Contains(v, "admin"),
+			IsOwner:        strings.Contains(v, "owner"),
+			IsPrimaryOwner: strings.Contains(v, "primary"),
+		}, nil
+	}
+
+	role, err := r.resolver.UserRole(ctx, i, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var flags []string
+	if role.IsAdmin {
+		flags = append(flags, "admin")
+	}
+	if role.IsOwner {
+		flags = append(flags, "owner")
+	}
+	if role.IsPrimaryOwner {
+		flags = append(flags, "primary")
+	}
+	r.store.Set(ctx, key, strings.Join(flags, ","), policyCacheExpiration)
+
+	return role, nil
+}
+
+// UsergroupMembers implements PolicyResolver
+func (r *webPolicyResolver) UsergroupMembers(ctx context.Context, i *Interaction, usergroupID string) ([]string, error) {
+	token, err := GetToken(ctx, i.TeamID(), i.EnterpriseID())
+	if err != nil {
+		return nil, err
+	}
+
+	var resp UsergroupUsers
+	if err := Get(ctx, token, "usergroups.users.list", "usergroup="+url.QueryEscape(usergroupID), &resp); err != nil {
+		return nil, err
+	}
+	if !resp.OK {
+		return nil, fmt.Errorf("usergroups.users.list: %s", resp.Error)
+	}
+	return resp.Users, nil
+}
+
+// UserRole implements PolicyResolver
+func (r *webPolicyResolver) UserRole(ctx context.Context, i *Interaction, userID string) (*UserRole, error) {
+	token, err := GetToken(ctx, i.TeamID(), i.EnterpriseID())
+	if err != nil {
+		return nil, err
+	}
+
+	var resp UserInfo
+	if err := Get(ctx, token, "users.info", "user="+url.QueryEscape(userID), &resp); err != nil {
+		return nil, err
+	}
+	if !resp.OK {
+		return nil, fmt.Errorf("users.info: %s", resp.Error)
+	}
+
+	return &UserRole{
+		IsAdmin:        resp.User.IsAdmin,
+		IsOwner:        resp.User.IsOwner,
+		IsPrimaryOwner: resp.User.IsPrimaryOwner,
+	}, nil
+}
+
+func contains(list []string, v string) bool {
+	for i := range list {
+		if list[i] == v {
+			return true
+		}
+	}
+	return false
+}
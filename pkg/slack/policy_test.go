@@ -0,0 +1,189 @@
+package slack
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+)
+
+// testResolver is a PolicyResolver with fixed memberships and roles
+type testResolver struct {
+	members map[string][]string
+	roles   map[string]*UserRole
+	calls   int
+}
+
+func (r *testResolver) UsergroupMembers(ctx context.Context, i *Interaction, usergroupID string) ([]string, error) {
+	r.calls++
+	return r.members[usergroupID], nil
+}
+
+func (r *testResolver) UserRole(ctx context.Context, i *Interaction, userID string) (*UserRole, error) {
+	r.calls++
+	if role, ok := r.roles[userID]; ok {
+		return role, nil
+	}
+	return &UserRole{}, nil
+}
+
+// testStore is an expiringStore in memory
+type testStore struct {
+	values map[string]string
+	now    int64
+}
+
+func (s *testStore) Get(ctx context.Context, key string) (string, bool) {
+	return expiringValue(s.values[key], s.now)
+}
+
+func (s *testStore) Set(ctx context.Context, key, value string, duration int64) error {
+	s.values[key] = fmt.Sprintf("%d|%s", s.now+duration, value)
+	return nil
+}
+
+func testInteraction(user, channel string) *Interaction {
+	return &Interaction{Type: InteractionSlashCommand, Name: "/deploy", Command: &SlashCommand{Command: "/deploy", UserID: user, ChannelID: channel, TeamID: "T1"}}
+}
+
+func TestAuthorize(t *testing.T) {
+	defer SetPolicyResolver(policyResolver)
+	SetPolicyResolver(&testResolver{
+		members: map[string][]string{"S1": {"U2"}},
+		roles:   map[string]*UserRole{"U3": {IsAdmin: true}, "U4": {IsOwner: true}},
+	})
+
+	tests := []struct {
+		name    string
+		policy  Policy
+		user    string
+		channel string
+		allowed bool
+	}{
+		{"no restrictions", Policy{}, "U1", "C1", true},
+		{"user", Policy{Users: []string{"U1"}}, "U1", "C1", true},
+		{"other user", Policy{Users: []string{"U1"}}, "U2", "C1", false},
+		{"usergroup member", Policy{Usergroups: []string{"S1"}}, "U2", "C1", true},
+		{"not a usergroup member", Policy{Usergroups: []string{"S1"}}, "U1", "C1", false},
+		{"admin", Policy{Admins: true}, "U3", "C1", true},
+		{"owner as admin", Policy{Admins: true}, "U4", "C1", true},
+		{"admin as owner", Policy{Owners: true}, "U3", "C1", false},
+		{"alternatives", Policy{Users: []string{"U1"}, Usergroups: []string{"S1"}}, "U2", "C1", true},
+		{"channel", Policy{Channels: []string{"C1"}}, "U1", "C1", true},
+		{"other channel", Policy{Channels: []string{"C1"}}, "U1", "C2", false},
+		{"no channel", Policy{Channels: []string{"C1"}}, "U1", "", true},
+		{"denied channel", Policy{DenyChannels: []string{"C1"}}, "U1", "C1", false},
+		{"user in other channel", Policy{Users: []string{"U1"}, Channels: []string{"C1"}}, "U1", "C2", false},
+		{"team", Policy{Teams: []string{"T1"}}, "U1", "C1", true},
+		{"other team", Policy{Teams: []string{"T2"}}, "U1", "C1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			h := Authorize(&tt.policy)(func(c *gin.Context, i *Interaction) (*SectionBlocks, error) {
+				called = true
+				return nil, nil
+			})
+
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("POST", "/", nil)
+
+			resp, err := h(c, testInteraction(tt.user, tt.channel))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if called != tt.allowed {
+				t.Errorf("handler called = %v, want %v", called, tt.allowed)
+			}
+			if !tt.allowed && (resp == nil || resp.ResponseType != SlackResponseTypeEphemeral) {
+				t.Errorf("denial = %+v, want an ephemeral message", resp)
+			}
+		})
+	}
+}
+
+func TestDenialMessage(t *testing.T) {
+	p := &Policy{Message: "Ask an admin."}
+	if got := p.denial(testInteraction("U1", "C1")).Text; got != "Ask an admin." {
+		t.Errorf("denial = %q, want the policy message", got)
+	}
+}
+
+func TestCachedPolicyResolver(t *testing.T) {
+	resolver := &testResolver{
+		members: map[string][]string{"S1": {"U1", "U2"}, "S2": nil},
+		roles:   map[string]*UserRole{"U1": {IsAdmin: true, IsOwner: true}},
+	}
+	store := &testStore{values: map[string]string{}, now: 1000}
+	r := &cachedPolicyResolver{resolver: resolver, store: store}
+	ctx := context.Background()
+	i := testInteraction("U1", "C1")
+
+	steps := []struct {
+		name     string
+		advance  int64
+		group    string
+		members  []string
+		resolved int // calls of the uncached resolver so far
+	}{
+		{"miss", 0, "S1", []string{"U1", "U2"}, 1},
+		{"hit", 0, "S1", []string{"U1", "U2"}, 1},
+		{"hit before expiration", policyCacheExpiration - 1, "S1", []string{"U1", "U2"}, 1},
+		{"expired", 1, "S1", []string{"U1", "U2"}, 2},
+		{"hit after refresh", 0, "S1", []string{"U1", "U2"}, 2},
+		{"empty group", 0, "S2", nil, 3},
+		{"empty group cached", 0, "S2", nil, 3},
+	}
+
+	for _, s := range steps {
+		store.now += s.advance
+		members, err := r.UsergroupMembers(ctx, i, s.group)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(members, s.members) || resolver.calls != s.resolved {
+			t.Errorf("%s: members = %q, resolver calls = %d, want %q, %d", s.name, members, resolver.calls, s.members, s.resolved)
+		}
+	}
+
+	resolver.calls = 0
+	want := UserRole{IsAdmin: true, IsOwner: true}
+	for n, resolved := range []int{1, 1} {
+		role, err := r.UserRole(ctx, i, "U1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *role != want || resolver.calls != resolved {
+			t.Errorf("step %d: role = %+v, resolver calls = %d, want %+v, %d", n, *role, resolver.calls, want, resolved)
+		}
+	}
+
+	store.now += policyCacheExpiration
+	if _, err := r.UserRole(ctx, i, "U1"); err != nil || resolver.calls != 2 {
+		t.Errorf("expired role was not resolved again, resolver calls = %d", resolver.calls)
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		list []string
+		v    string
+		want bool
+	}{
+		{nil, "U1", false},
+		{[]string{}, "", false},
+		{[]string{"U1", "U2"}, "U2", true},
+		{[]string{"U1", "U2"}, "U3", false},
+		{[]string{"U1"}, "u1", false},
+	}
+
+	for _, tt := range tests {
+		if got := contains(tt.list, tt.v); got != tt.want {
+			t.Errorf("contains(%q, %q) = %v, want %v", tt.list, tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// BlockKitBuilderURL is the base URL of Slack's Block Kit Builder
+const BlockKitBuilderURL = "https://app.slack.com/block-kit-builder/"
+
+var (
+	mrkdwnBold   = regexp.MustCompile(`(^|[\s(])\*([^*\n]+)\*`)
+	mrkdwnItalic = regexp.MustCompile(`(^|[\s(])_([^_\n]+)_`)
+	mrkdwnStrike = regexp.MustCompile(`(^|[\s(])~([^~\n]+)~`)
+	mrkdwnCode   = regexp.MustCompile("`([^`\n]+)`")
+)
+
+const previewStyle = `body{font-family:-apple-system,"Segoe UI",Helvetica,Arial,sans-serif;font-size:15px;color:#1d1c1d;background:#f8f8f8}
+.surface{max-width:520px;margin:24px auto;background:#fff;border:1px solid #ddd;border-radius:8px;padding:16px 24px}
+.title{font-size:22px;font-weight:900;margin-bottom:16px}
+.block{margin:8px 0}
+.section{display:flex;gap:12px;justify-content:space-between}
+.fields{display:grid;grid-template-columns:1fr 1fr;gap:8px;margin-top:8px}
+.header{font-size:18px;font-weight:900}
+.context{font-size:12px;color:#616061;display:flex;gap:8px;align-items:center}
+.context img{width:20px;height:20px}
+.label{font-weight:700;margin-bottom:4px}
+.optional{font-weight:400;color:#616061}
+.hint{font-size:12px;color:#616061;margin-top:4px}
+.input{border:1px solid #bbb;border-radius:4px;padding:8px;color:#616061;min-height:18px}
+.button{display:inline-block;border:1px solid #bbb;border-radius:4px;padding:4px 12px;font-weight:700;margin-right:8px}
+.primary{background:#007a5a;color:#fff;border-color:#007a5a}
+.danger{background:#e01e5a;color:#fff;border-color:#e01e5a}
+.footer{text-align:right;margin-top:16px}
+.unknown{color:#e01e5a;font-family:monospace}
+img.image{max-width:100%}
+pre,code{background:#f6f6f6;border:1px solid #ddd;border-radius:3px;font-family:monospace}
+blockquote{border-left:4px solid #ddd;margin:0;padding-left:8px}
+.mention{background:#e8f5fa;color:#1264a3}`
+
+// BuilderURL returns a link that opens a view or message in Block Kit Builder. The payload
+// is a ViewElement, ModalRequest, SectionBlocks, a list of blocks or anything that marshals
+// into one of them. The team ID is optional.
+func BuilderURL(teamID string, payload interface{}) (string, error) {
+	b, err := builderPayload(payload)
+	if err != nil {
+		return "", err
+	}
+	fragment := strings.Replace(url.QueryEscape(string(b)), "+", "%20", -1)
+	return BlockKitBuilderURL + teamID + "#" + fragment, nil
+}
+
+// PreviewHTML renders an approximation of a view or message as a standalone HTML page,
+// e.g. to review layouts without access to Slack. Blocks that are not supported are
+// shown as placeholders.
+func PreviewHTML(w io.Writer, payload interface{}) error {
+	var surface ViewElement
+
+	switch p := payload.(type) {
+	case ViewElement:
+		surface = p
+	case *ViewElement:
+		surface = *p
+	case ModalRequest:
+		surface = p.View
+	case *ModalRequest:
+		surface = p.View
+	case SectionBlocks:
+		surface.Blocks = p.Blocks
+	case *SectionBlocks:
+		surface.Blocks = p.Blocks
+	case []Block:
+		surface.Blocks = p
+	default:
+		return fmt.Errorf("can not preview %T", payload)
+	}
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>Block Kit preview</title><style>")
+	b.WriteString(previewStyle)
+	b.WriteString("</style></head><body><div class=\"surface\">\n")
+
+	if surface.Title.Text != "" {
+		b.WriteString("<div class=\"title\">" + html.EscapeString(surface.Title.Text) + "</div>\n")
+	}
+	for _, block := range surface.Blocks {
+		previewBlock(&b, block)
+	}
+	if surface.Type == "modal" {
+		b.WriteString("<div class=\"footer\">")
+		if surface.Close != nil {
+			b.WriteString("<span class=\"button\">" + html.EscapeString(surface.Close.Text) + "</span>")
+		} else {
+			b.WriteString("<span class=\"button\">Cancel</span>")
+		}
+		if surface.Submit != nil {
+			b.WriteString("<span class=\"button primary\">" + html.EscapeString(surface.Submit.Text) + "</span>")
+		}
+		b.WriteString("</div>\n")
+	}
+
+	b.WriteString("</div></body></html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// builderPayload returns the JSON Block Kit Builder expects for a payload
+func builderPayload(payload interface{}) ([]byte, error) {
+	switch p := payload.(type) {
+	case ModalRequest:
+		return json.Marshal(builderView(p.View))
+	case *ModalRequest:
+		return json.Marshal(builderView(p.View))
+	case ViewElement:
+		return json.Marshal(builderView(p))
+	case *ViewElement:
+		return json.Marshal(builderView(*p))
+	case []Block:
+		return json.Marshal(SectionBlocks{Blocks: p})
+	}
+	return json.Marshal(payload)
+}
+
+// builderView removes the fields Slack adds to views, Block Kit Builder rejects them
+func builderView(v ViewElement) ViewElement {
+	return ViewElement{
+		Type:            v.Type,
+		Title:           v.Title,
+		Submit:          v.Submit,
+		Close:           v.Close,
+		Blocks:          v.Blocks,
+		PrivateMetadata: v.PrivateMetadata,
+		CallbackID:      v.CallbackID,
+		ExternalID:      v.ExternalID,
+	}
+}
+
+func previewBlock(b *strings.Builder, block Block) {
+	if isNil(block) {
+		return
+	}
+
+	switch bl := pointerTo(block).(type) {
+	case *SectionBlock:
+		b.WriteString("<div class=\"block section\"><div>")
+		if bl.Text.Text != "" {
+			b.WriteString(previewText(bl.Text))
+		}
+		if len(bl.Fields) > 0 {
+			b.WriteString("<div class=\"fields\">")
+			for _, f := range bl.Fields {
+				b.WriteString("<div>" + previewText(f) + "</div>")
+			}
+			b.WriteString("</div>")
+		}
+		b.WriteString("</div>")
+		if bl.Accessory != nil {
+			b.WriteString("<div>" + previewElement(bl.Accessory) + "</div>")
+		}
+		b.WriteString("</div>\n")
+	case *DividerBlock:
+		b.WriteString("<hr>\n")
+	case *HeaderBlock:
+		b.WriteString("<div class=\"block header\">" + html.EscapeString(bl.Text.Text) + "</div>\n")
+	case *ContextBlock:
+		b.WriteString("<div class=\"block context\">")
+		for _, e := range bl.Elements {
+			b.WriteString(previewContextElement(e))
+		}
+		b.WriteString("</div>\n")
+	case *ImageBlock:
+		b.WriteString("<div class=\"block\">")
+		if bl.Title != nil {
+			b.WriteString("<div>" + html.EscapeString(bl.Title.Text) + "</div>")
+		}
+		b.WriteString("<img class=\"image\" src=\"" + html.EscapeString(bl.ImageURL) + "\" alt=\"" + html.EscapeString(bl.AltText) + "\"></div>\n")
+	case *ActionsBlock:
+		b.WriteString("<div class=\"block\">")
+		for _, e := range bl.Elements {
+			b.WriteString(previewElement(e))
+		}
+		b.WriteString("</div>\n")
+	case *InputBlock:
+		b.WriteString("<div class=\"block\"><div class=\"label\">" + html.EscapeString(bl.Label.Text))
+		if bl.Optional {
+			b.WriteString(" <span class=\"optional\">(optional)</span>")
+		}
+		b.WriteString("</div>" + previewElement(bl.Element))
+		if bl.Hint != nil {
+			b.WriteString("<div class=\"hint\">" + html.EscapeString(bl.Hint.Text) + "</div>")
+		}
+		b.WriteString("</div>\n")
+	case *RichTextBlock:
+		b.WriteString("<div class=\"block\">" + previewMrkdwn(MarkdownToMrkdwn(bl.Markdown())) + "</div>\n")
+	default:
+		b.WriteString("<div class=\"block unknown\">[" + html.EscapeString(block.BlockType()) + " block]</div>\n")
+	}
+}
+
+// previewContextElement renders a text or image of a context block
+func previewContextElement(e ContextElement) string {
+	switch el := pointerTo(e).(type) {
+	case *TextObject:
+		return "<span>" + previewText(*el) + "</span>"
+	case *ImageElement:
+		return previewElement(el)
+	}
+	return ""
+}
+
+// previewElement renders an element from its JSON representation, most elements share
+// the same fields
+func previewElement(e BlockElement) string {
+	if isNil(e) {
+		return ""
+	}
+
+	switch el := pointerTo(e).(type) {
+	case *ImageElement:
+		return "<img src=\"" + html.EscapeString(el.ImageURL) + "\" alt=\"" + html.EscapeString(el.AltText) + "\">"
+	case *Button:
+		class := "button"
+		if el.Style != "" {
+			class += " " + el.Style
+		}
+		return "<span class=\"" + html.EscapeString(class) + "\">" + html.EscapeString(el.Text.Text) + "</span>"
+	}
+
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return ""
+	}
+	var fields struct {
+		Placeholder  *TextObject     `json:"placeholder"`
+		InitialValue string          `json:"initial_value"`
+		Options      []OptionsObject `json:"options"`
+		Multiline    bool            `json:"multiline"`
+	}
+	json.Unmarshal(raw, &fields)
+
+	switch e.ElementType() {
+	case "checkboxes", "radio_buttons":
+		marker := "☐"
+		if e.ElementType() == "radio_buttons" {
+			marker = "○"
+		}
+		var b strings.Builder
+		for _, o := range fields.Options {
+			b.WriteString("<div>" + marker + " " + previewText(o.Text) + "</div>")
+		}
+		return b.String()
+	case "overflow":
+		return "<span class=\"button\">⋯</span>"
+	}
+
+	text := fields.InitialValue
+	if text == "" && fields.Placeholder != nil {
+		text = fields.Placeholder.Text
+	}
+	if strings.Contains(e.ElementType(), "select") {
+		text += " ▾"
+	}
+	style := ""
+	if fields.Multiline {
+		style = " style=\"min-height:64px\""
+	}
+	return "<div class=\"input\"" + style + ">" + html.EscapeString(text) + "</div>"
+}
+
+func previewText(t TextObject) string {
+	if t.Type == "mrkdwn" {
+		return previewMrkdwn(t.Text)
+	}
+	return strings.Replace(html.EscapeString(t.Text), "\n", "<br>", -1)
+}
+
+// previewMrkdwn approximates mrkdwn formatting in HTML
+func previewMrkdwn(text string) string {
+	var b strings.Builder
+	last := 0
+
+	for _, m := range entityPattern.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(previewFormat(text[last:m[0]]))
+		last = m[1]
+
+		content, label := text[m[2]:m[3]], ""
+		if i := strings.Index(content, "|"); i >= 0 {
+			content, label = content[:i], content[i+1:]
+		}
+
+		switch {
+		case strings.HasPrefix(content, "@"), strings.HasPrefix(content, "#"):
+			if label == "" {
+				label = content
+			}
+			b.WriteString("<span class=\"mention\">" + html.EscapeString(Unescape(label)) + "</span>")
+		case strings.HasPrefix(content, "!subteam^"):
+			b.WriteString("<span class=\"mention\">@" + html.EscapeString(strings.TrimPrefix(content, "!subteam^")) + "</span>")
+		case strings.HasPrefix(content, "!date^"):
+			b.WriteString(html.EscapeString(Unescape(label)))
+		case strings.HasPrefix(content, "!"):
+			b.WriteString("<span class=\"mention\">@" + html.EscapeString(content[1:]) + "</span>")
+		default:
+			if label == "" {
+				label = content
+			}
+			if href := safeLink(Unescape(content)); href != "" {
+				b.WriteString("<a href=\"" + html.EscapeString(href) + "\">" + previewFormat(label) + "</a>")
+			} else {
+				b.WriteString(previewFormat(label))
+			}
+		}
+	}
+	b.WriteString(previewFormat(text[last:]))
+
+	return b.String()
+}
+
+// safeLink returns the link if it is a http, https or mailto URL, and an empty string otherwise
+func safeLink(link string) string {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return ""
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "mailto":
+		return u.String()
+	}
+	return ""
+}
+
+// previewFormat converts mrkdwn formatting of text without entities
+func previewFormat(text string) string {
+	var b strings.Builder
+
+	for i, part := range strings.Split(text, "```") {
+		s := html.EscapeString(Unescape(part))
+		if i%2 == 1 {
+			b.WriteString("<pre>" + strings.Trim(s, "\n") + "</pre>")
+			continue
+		}
+
+		s = mrkdwnCode.ReplaceAllString(s, "<code>$1</code>")
+		s = mrkdwnBold.ReplaceAllString(s, "$1<b>$2</b>")
+		s = mrkdwnItalic.ReplaceAllString(s, "$1<i>$2</i>")
+		s = mrkdwnStrike.ReplaceAllString(s, "$1<s>$2</s>")
+
+		lines := strings.Split(s, "\n")
+		for j, l := range lines {
+			if strings.HasPrefix(l, "&gt;") {
+				lines[j] = "<blockquote>" + strings.TrimPrefix(l, "&gt;") + "</blockquote>"
+			} else if j < len(lines)-1 {
+				lines[j] = l + "<br>"
+			}
+		}
+		b.WriteString(strings.Join(lines, ""))
+	}
+
+	return b.String()
+}
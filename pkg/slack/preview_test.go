@@ -0,0 +1,31 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPreviewMrkdwnLinks(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"<https://example.com|Example>", `<a href="https://example.com">Example</a>`},
+		{"<http://example.com>", `<a href="http://example.com">http://example.com</a>`},
+		{"<mailto:a@example.com|mail>", `<a href="mailto:a@example.com">mail</a>`},
+		{"<javascript:alert(1)|x>", "x"},
+		{"<JavaScript:alert(1)>", "JavaScript:alert(1)"},
+		{"<data:text/html;base64,PHNjcmlwdD4=|x>", "x"},
+		{"<vbscript:msgbox|x>", "x"},
+	}
+
+	for _, tt := range tests {
+		got := previewMrkdwn(tt.text)
+		if got != tt.want {
+			t.Errorf("previewMrkdwn(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+		if strings.Contains(strings.ToLower(got), "href=\"javascript") {
+			t.Errorf("previewMrkdwn(%q) links a script", tt.text)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package slack
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/txsvc/platform/pkg/platform"
+)
+
+// Scopes of rate limits
+const (
+	RateLimitUser    = "user"
+	RateLimitChannel = "channel"
+	RateLimitTeam    = "team"
+)
+
+// sweep the in-memory buckets after this many requests
+const rateLimitSweepInterval = 1000
+
+type (
+	// RateLimit allows a number of requests per period, e.g. 5 per minute. Requests can
+	// come in bursts as long as the average rate is not exceeded (token bucket).
+	RateLimit struct {
+		Requests int
+		Per      time.Duration
+	}
+
+	// RateLimitBucket is the token bucket of a user, channel or team
+	RateLimitBucket struct {
+		Scope string
+		Key   string
+		Limit RateLimit
+	}
+
+	// RateLimitConfig configures a Limiter. Limits that are nil are not enforced.
+	RateLimitConfig struct {
+		User       *RateLimit
+		Channel    *RateLimit
+		Team       *RateLimit
+		PerCommand bool             // separate buckets for each command or action
+		Backend    RateLimitBackend // defaults to an in-memory backend
+		Message    string           // replaces the standard "slow down" message
+	}
+
+	// RateLimitBackend keeps the token buckets. Implement it to share limits between instances,
+	// e.g. with Redis or Memcache.
+	RateLimitBackend interface {
+		// Take takes a token from every bucket, but only if all of them have one. Otherwise no
+		// token is taken, and it returns an empty bucket and the time until all buckets have a token again.
+		Take(buckets []RateLimitBucket) (*RateLimitBucket, time.Duration, error)
+	}
+
+	// RateLimitStats are counters for monitoring
+	RateLimitStats struct {
+		Allowed        uint64
+		Limited        uint64
+		LimitedUser    uint64
+		LimitedChannel uint64
+		LimitedTeam    uint64
+		BackendErrors  uint64
+	}
+
+	// Limiter is a rate limiting middleware
+	Limiter struct {
+		config RateLimitConfig
+		stats  RateLimitStats
+	}
+
+	// MemoryRateLimitBackend keeps the token buckets in memory
+	MemoryRateLimitBackend struct {
+		mu       sync.Mutex
+		buckets  map[string]*tokenBucket
+		requests int
+		now      func() time.Time
+	}
+
+	tokenBucket struct {
+		tokens float64
+		last   time.Time
+		limit  RateLimit
+	}
+)
+
+// NewLimiter creates a rate limiter, add its middleware with Use, UseSlashCmd or UseAction
+func NewLimiter(config RateLimitConfig) (*Limiter, error) {
+	for scope, limit := range map[string]*RateLimit{RateLimitUser: config.User, RateLimitChannel: config.Channel, RateLimitTeam: config.Team} {
+		if limit != nil && (limit.Requests <= 0 || limit.Per <= 0) {
+			return nil, fmt.Errorf("invalid %s rate limit %d per %v", scope, limit.Requests, limit.Per)
+		}
+	}
+	if config.Backend == nil {
+		config.Backend = NewMemoryRateLimitBackend()
+	}
+	return &Limiter{config: config}, nil
+}
+
+// Middleware returns the middleware enforcing the limits. Limited users get an ephemeral
+// message, the handler is not called. Backend errors don't block requests.
+func (l *Limiter) Middleware() Middleware {
+	return func(next InteractionFunc) InteractionFunc {
+		return func(c *gin.Context, i *Interaction) (*SectionBlocks, error) {
+			if wait, limited := l.limited(i); limited {
+				atomic.AddUint64(&l.stats.Limited, 1)
+				return l.slowDown(i, wait), nil
+			}
+			atomic.AddUint64(&l.stats.Allowed, 1)
+			return next(c, i)
+		}
+	}
+}
+
+// Stats returns a snapshot of the counters
+func (l *Limiter) Stats() RateLimitStats {
+	return RateLimitStats{
+		Allowed:        atomic.LoadUint64(&l.stats.Allowed),
+		Limited:        atomic.LoadUint64(&l.stats.Limited),
+		LimitedUser:    atomic.LoadUint64(&l.stats.LimitedUser),
+		LimitedChannel: atomic.LoadUint64(&l.stats.LimitedChannel),
+		LimitedTeam:    atomic.LoadUint64(&l.stats.LimitedTeam),
+		BackendErrors:  atomic.LoadUint64(&l.stats.BackendErrors),
+	}
+}
+
+// limited checks the user, channel and team limits. A token is taken from each bucket
+// only if none of them is empty.
+func (l *Limiter) limited(i *Interaction) (time.Duration, bool) {
+	scopes := []struct {
+		scope string
+		id    string
+		limit *RateLimit
+	}{
+		{RateLimitUser, i.UserID(), l.config.User},
+		{RateLimitChannel, i.ChannelID(), l.config.Channel},
+		{RateLimitTeam, i.ClientID(), l.config.Team},
+	}
+
+	var buckets []RateLimitBucket
+	for _, s := range scopes {
+		if s.limit == nil || s.id == "" {
+			continue
+		}
+
+		key := "ratelimit." + s.scope + "." + i.ClientID() + "." + s.id
+		if l.config.PerCommand {
+			key += "." + i.Type + "." + i.Name
+		}
+		buckets = append(buckets, RateLimitBucket{Scope: s.scope, Key: key, Limit: *s.limit})
+	}
+	if len(buckets) == 0 {
+		return 0, false
+	}
+
+	empty, wait, err := l.config.Backend.Take(buckets)
+	if err != nil {
+		atomic.AddUint64(&l.stats.BackendErrors, 1)
+		platform.ReportError(err)
+		return 0, false
+	}
+	if empty == nil {
+		return 0, false
+	}
+
+	switch empty.Scope {
+	case RateLimitUser:
+		atomic.AddUint64(&l.stats.LimitedUser, 1)
+	case RateLimitChannel:
+		atomic.AddUint64(&l.stats.LimitedChannel, 1)
+	case RateLimitTeam:
+		atomic.AddUint64(&l.stats.LimitedTeam, 1)
+	}
+	return wait, true
+}
+
+// slowDown is the standard ephemeral message for limited users
+func (l *Limiter) slowDown(i *Interaction, wait time.Duration) *SectionBlocks {
+	text := l.config.Message
+	if text == "" {
+		seconds := int(math.Ceil(wait.Seconds()))
+		when := fmt.Sprintf("%d seconds", seconds)
+		if seconds <= 1 {
+			when = "a second"
+		}
+		what := "this"
+		if i.Type == InteractionSlashCommand {
+			what = Code(Escape(i.Name))
+		}
+		text = fmt.Sprintf(":hourglass: Slow down! Please wait %s before you use %s again.", when, what)
+	}
+	return NewEphemeralResponse(text, &SectionBlock{Text: TextObject{Type: "mrkdwn", Text: text}})
+}
+
+// NewMemoryRateLimitBackend creates an in-memory backend, limits are not shared between instances
+func NewMemoryRateLimitBackend() *MemoryRateLimitBackend {
+	return &MemoryRateLimitBackend{buckets: make(map[string]*tokenBucket), now: time.Now}
+}
+
+// Take implements RateLimitBackend
+func (m *MemoryRateLimitBackend) Take(buckets []RateLimitBucket) (*RateLimitBucket, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.requests++
+	if m.requests%rateLimitSweepInterval == 0 {
+		m.sweep(now)
+	}
+
+	var empty *RateLimitBucket
+	var wait time.Duration
+
+	tbs := make([]*tokenBucket, len(buckets))
+	for i := range buckets {
+		limit := buckets[i].Limit
+		if limit.Requests <= 0 || limit.Per <= 0 {
+			return nil, 0, fmt.Errorf("invalid rate limit %d per %v", limit.Requests, limit.Per)
+		}
+
+		b := m.buckets[buckets[i].Key]
+		if b == nil {
+			b = &tokenBucket{tokens: float64(limit.Requests), last: now, limit: limit}
+			m.buckets[buckets[i].Key] = b
+		}
+		b.refill(now)
+		tbs[i] = b
+
+		if b.tokens < 1 {
+			if empty == nil {
+				empty = &buckets[i]
+			}
+			if w := time.Duration((1 - b.tokens) / b.rate() * float64(time.Second)); w > wait {
+				wait = w
+			}
+		}
+	}
+	if empty != nil {
+		return empty, wait, nil
+	}
+
+	for _, b := range tbs {
+		b.tokens--
+	}
+	return nil, 0, nil
+}
+
+// sweep removes buckets that are full again, they are the same as new ones
+func (m *MemoryRateLimitBackend) sweep(now time.Time) {
+	for k, b := range m.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Requests) {
+			delete(m.buckets, k)
+		}
+	}
+}
+
+// rate returns the tokens added per second
+func (b *tokenBucket) rate() float64 {
+	return float64(b.limit.Requests) / b.limit.Per.Seconds()
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens = math.Min(float64(b.limit.Requests), b.tokens+now.Sub(b.last).Seconds()*b.rate())
+	b.last = now
+}
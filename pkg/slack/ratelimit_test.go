@@ -0,0 +1,132 @@
+package slack
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func testBackend(now *time.Time) *MemoryRateLimitBackend {
+	m := NewMemoryRateLimitBackend()
+	m.now = func() time.Time { return *now }
+	return m
+}
+
+func TestMemoryRateLimitBackend(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	m := testBackend(&now)
+	user := []RateLimitBucket{{Scope: RateLimitUser, Key: "u", Limit: RateLimit{Requests: 2, Per: time.Minute}}}
+
+	steps := []struct {
+		advance time.Duration
+		allowed bool
+		wait    time.Duration
+	}{
+		{0, true, 0},
+		{0, true, 0},
+		{0, false, 30 * time.Second},
+		{10 * time.Second, false, 20 * time.Second},
+		{20 * time.Second, true, 0},
+		{0, false, 30 * time.Second},
+		{2 * time.Minute, true, 0},
+		{0, true, 0},
+		{0, false, 30 * time.Second},
+	}
+
+	for n, s := range steps {
+		now = now.Add(s.advance)
+		empty, wait, err := m.Take(user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (empty == nil) != s.allowed || wait != s.wait {
+			t.Errorf("step %d: allowed = %v, wait = %v, want %v, %v", n, empty == nil, wait, s.allowed, s.wait)
+		}
+	}
+}
+
+func TestMemoryRateLimitBackendTakesAllOrNothing(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	m := testBackend(&now)
+
+	user := RateLimitBucket{Scope: RateLimitUser, Key: "u", Limit: RateLimit{Requests: 3, Per: time.Minute}}
+	team := RateLimitBucket{Scope: RateLimitTeam, Key: "t", Limit: RateLimit{Requests: 1, Per: time.Minute}}
+
+	if empty, _, _ := m.Take([]RateLimitBucket{user, team}); empty != nil {
+		t.Fatal("first request was limited")
+	}
+	for n := 0; n < 5; n++ {
+		empty, _, _ := m.Take([]RateLimitBucket{user, team})
+		if empty == nil || empty.Scope != RateLimitTeam {
+			t.Fatalf("retry %d: want the team limit, got %v", n, empty)
+		}
+	}
+
+	// the rejected requests must not have used up the user's tokens
+	if empty, _, _ := m.Take([]RateLimitBucket{user}); empty != nil {
+		t.Error("user bucket was drained by rejected requests")
+	}
+	if empty, _, _ := m.Take([]RateLimitBucket{user}); empty != nil {
+		t.Error("user bucket was drained by rejected requests")
+	}
+	if empty, _, _ := m.Take([]RateLimitBucket{user}); empty == nil {
+		t.Error("user bucket should be empty")
+	}
+}
+
+func TestNewLimiterValidatesConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  RateLimitConfig
+		wantErr bool
+	}{
+		{"empty", RateLimitConfig{}, false},
+		{"valid", RateLimitConfig{User: &RateLimit{Requests: 5, Per: time.Minute}}, false},
+		{"no requests", RateLimitConfig{User: &RateLimit{Per: time.Minute}}, true},
+		{"no period", RateLimitConfig{Team: &RateLimit{Requests: 5}}, true},
+		{"negative", RateLimitConfig{Channel: &RateLimit{Requests: -1, Per: time.Minute}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewLimiter(tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("NewLimiter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLimiterMiddleware(t *testing.T) {
+	l, err := NewLimiter(RateLimitConfig{
+		User: &RateLimit{Requests: 2, Per: time.Minute},
+		Team: &RateLimit{Requests: 3, Per: time.Minute},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	h := l.Middleware()(func(c *gin.Context, i *Interaction) (*SectionBlocks, error) {
+		calls++
+		return nil, nil
+	})
+
+	for _, user := range []string{"U1", "U1", "U1", "U2", "U3"} {
+		i := &Interaction{Type: InteractionSlashCommand, Name: "/deploy", Command: &SlashCommand{Command: "/deploy", UserID: user, TeamID: "T1"}}
+		resp, err := h(nil, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp != nil && resp.ResponseType != SlackResponseTypeEphemeral {
+			t.Errorf("response type = %q, want ephemeral", resp.ResponseType)
+		}
+	}
+
+	if calls != 3 {
+		t.Errorf("handler calls = %d, want 3", calls)
+	}
+	want := RateLimitStats{Allowed: 3, Limited: 2, LimitedUser: 1, LimitedTeam: 1}
+	if got := l.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package slack
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+const (
+	// SlackOAuthSuccessURL is the page users are sent to after a successful installation
+	SlackOAuthSuccessURL string = "SLACK_OAUTH_SUCCESS_URL"
+	// SlackOAuthFailureURL is the page users are sent to if the installation failed
+	SlackOAuthFailureURL string = "SLACK_OAUTH_FAILURE_URL"
+	// SlackOAuthCancelURL is the page users are sent to if they declined the installation
+	SlackOAuthCancelURL string = "SLACK_OAUTH_CANCEL_URL"
+	// SlackOAuthRedirectAllowList is a comma separated list of URLs the redirect_uri parameter may point to
+	SlackOAuthRedirectAllowList string = "SLACK_OAUTH_REDIRECT_ALLOW_LIST"
+
+	// OAuthErrorAccessDenied is sent by Slack when the user declined the installation
+	OAuthErrorAccessDenied string = "access_denied"
+	// OAuthErrorSlack is reported when Slack returned any other error
+	OAuthErrorSlack string = "slack_error"
+	// OAuthErrorTokenExchange is reported when the temporary code could not be exchanged
+	OAuthErrorTokenExchange string = "token_exchange_failed"
+	// OAuthErrorTeamInfo is reported when the team of the installation could not be determined
+	OAuthErrorTeamInfo string = "team_info_failed"
+	// OAuthErrorStorage is reported when the installation could not be stored
+	OAuthErrorStorage string = "storage_failed"
+	// OAuthErrorInvalidState is reported when a sign-in callback has an unknown or expired state
+	OAuthErrorInvalidState string = "invalid_state"
+	// OAuthErrorInvalidIDToken is reported when the ID token of a sign-in could not be verified
+	OAuthErrorInvalidIDToken string = "invalid_id_token"
+	// OAuthErrorSignIn is reported when the sign-in could not be started or the sign-in handler failed
+	OAuthErrorSignIn string = "sign_in_failed"
+
+	defaultSuccessURL = "/"
+	defaultFailureURL = "/error"
+)
+
+// redirect targets added in code, in addition to SLACK_OAUTH_REDIRECT_ALLOW_LIST
+var allowedRedirects []string
+var allowedRedirectsMutex sync.Mutex
+
+// AllowRedirect adds URLs the redirect_uri parameter of OAuthEndpoint may point to.
+// A redirect is allowed if scheme and host match and its path is the allowed path or below it,
+// e.g. /app allows /app and /app/settings, but not /app.evil or /application.
+// Relative paths on the same host are always allowed.
+func AllowRedirect(urls ...string) {
+	allowedRedirectsMutex.Lock()
+	defer allowedRedirectsMutex.Unlock()
+
+	allowedRedirects = append(allowedRedirects, urls...)
+}
+
+// IsAllowedRedirect verifies that a redirect target is a local path or on the allow-list
+func IsAllowedRedirect(target string) bool {
+	if target == "" {
+		return false
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	// local paths, but not protocol-relative URLs like //evil.com or /\evil.com
+	if u.Scheme == "" && u.Host == "" {
+		return strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "//") && !strings.HasPrefix(target, "/\\")
+	}
+
+	for _, a := range redirectAllowList() {
+		allowed, err := url.Parse(a)
+		if err != nil || allowed.Host == "" {
+			continue
+		}
+		if strings.EqualFold(u.Scheme, allowed.Scheme) && strings.EqualFold(u.Host, allowed.Host) && isSubPath(u.Path, allowed.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSubPath returns true if p equals base or is below it, matching whole path segments only
+func isSubPath(p, base string) bool {
+	base = strings.TrimSuffix(base, "/")
+	if base == "" {
+		return true
+	}
+	if p == "" {
+		return false
+	}
+	p = path.Clean(p)
+	return p == base || strings.HasPrefix(p, base+"/")
+}
+
+func redirectAllowList() []string {
+	allowedRedirectsMutex.Lock()
+	defer allowedRedirectsMutex.Unlock()
+
+	list := append([]string{}, allowedRedirects...)
+	for _, a := range strings.Split(os.Getenv(SlackOAuthRedirectAllowList), ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			list = append(list, a)
+		}
+	}
+	return list
+}
+
+// oauthSuccessURL returns the requested redirect if it is allowed, the configured success URL otherwise
+func oauthSuccessURL(redirectURI string) string {
+	if IsAllowedRedirect(redirectURI) {
+		return redirectURI
+	}
+	return envURL(SlackOAuthSuccessURL, defaultSuccessURL)
+}
+
+// oauthFailureURL returns the failure URL with the reason added as query parameter 'error'.
+// Only the predefined OAuthError* codes are passed, never internal error messages.
+func oauthFailureURL(reason string) string {
+	return withQueryParam(envURL(SlackOAuthFailureURL, defaultFailureURL), "error", reason)
+}
+
+// oauthCancelURL returns the page users are sent to if they declined the installation
+func oauthCancelURL() string {
+	cancelURL := os.Getenv(SlackOAuthCancelURL)
+	if cancelURL == "" {
+		return oauthFailureURL(OAuthErrorAccessDenied)
+	}
+	return cancelURL
+}
+
+func envURL(env, def string) string {
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+	return def
+}
+
+func withQueryParam(target, key, value string) string {
+	u, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
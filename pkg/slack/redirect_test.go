@@ -0,0 +1,40 @@
+package slack
+
+import "testing"
+
+func TestIsAllowedRedirect(t *testing.T) {
+	AllowRedirect("https://example.com/dash", "https://example.com/app/", "https://root.example.com")
+	defer func() { allowedRedirects = nil }()
+
+	tests := []struct {
+		target string
+		want   bool
+	}{
+		{"/welcome", true},
+		{"", false},
+		{"//evil.com", false},
+		{"/\\evil.com", false},
+		{"https://example.com/dash", true},
+		{"https://example.com/dash/", true},
+		{"https://example.com/dash/settings?tab=1", true},
+		{"https://EXAMPLE.com/dash", true},
+		{"https://example.com/dashboard-evil", false},
+		{"https://example.com/dash.evil", false},
+		{"https://example.com/dash/../admin", false},
+		{"https://example.com/app", true},
+		{"https://example.com/app/x", true},
+		{"https://example.com/app.evil", false},
+		{"https://example.com/", false},
+		{"http://example.com/dash", false},
+		{"https://evil.com/dash", false},
+		{"https://example.com.evil.com/dash", false},
+		{"https://root.example.com/anything", true},
+		{"javascript:alert(1)", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsAllowedRedirect(tt.target); got != tt.want {
+			t.Errorf("IsAllowedRedirect(%q) = %v, want %v", tt.target, got, tt.want)
+		}
+	}
+}
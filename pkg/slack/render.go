@@ -0,0 +1,215 @@
+package slack
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// markdownEscaper escapes the characters that have a meaning in CommonMark
+var markdownEscaper = strings.NewReplacer(
+	"\\", "\\\\", "*", "\\*", "_", "\\_", "`", "\\`", "~", "\\~",
+	"[", "\\[", "]", "\\]", "<", "\\<", ">", "\\>", "#", "\\#",
+)
+
+// PlainText renders the block as plain text. Mentions are rendered as @U123, #C123 etc.
+func (b *RichTextBlock) PlainText() string {
+	return renderRichText(b.Elements, false)
+}
+
+// Markdown renders the block as CommonMark
+func (b *RichTextBlock) Markdown() string {
+	return renderRichText(b.Elements, true)
+}
+
+// PlainText returns what the user wrote. The rich_text blocks are used if the message has any,
+// the text of the message otherwise.
+func (m *ActionRequestMessage) PlainText() string {
+	if rt := m.richText(); len(rt) > 0 {
+		parts := make([]string, len(rt))
+		for i := range rt {
+			parts[i] = rt[i].PlainText()
+		}
+		return strings.Join(parts, "\n")
+	}
+	return Unescape(m.Text)
+}
+
+// Markdown returns what the user wrote as CommonMark. The rich_text blocks are used if the
+// message has any, the text of the message otherwise.
+func (m *ActionRequestMessage) Markdown() string {
+	if rt := m.richText(); len(rt) > 0 {
+		parts := make([]string, len(rt))
+		for i := range rt {
+			parts[i] = rt[i].Markdown()
+		}
+		return strings.Join(parts, "\n\n")
+	}
+	return Unescape(m.Text)
+}
+
+// richText returns the rich_text blocks of the message
+func (m *ActionRequestMessage) richText() []*RichTextBlock {
+	var rt []*RichTextBlock
+	for _, b := range m.Blocks {
+		if r, ok := b.(*RichTextBlock); ok {
+			rt = append(rt, r)
+		}
+	}
+	return rt
+}
+
+func renderRichText(elements []RichTextElement, markdown bool) string {
+	parts := make([]string, 0, len(elements))
+	lists := make([]bool, 0, len(elements))
+
+	for _, e := range elements {
+		var s string
+
+		switch e := e.(type) {
+		case *RichTextSection:
+			s = strings.TrimRight(renderInlines(e.Elements, markdown), "\n")
+			if markdown {
+				s = hardLineBreaks(s)
+			}
+		case *RichTextList:
+			lines := make([]string, len(e.Elements))
+			for i := range e.Elements {
+				bullet := "•"
+				if markdown {
+					bullet = "-"
+				}
+				if e.Style == "ordered" {
+					bullet = strconv.Itoa(e.Offset+i+1) + "."
+				}
+				item := strings.TrimRight(renderInlines(e.Elements[i].Elements, markdown), "\n")
+				lines[i] = strings.Repeat("    ", e.Indent) + bullet + " " + item
+			}
+			s = strings.Join(lines, "\n")
+		case *RichTextQuote:
+			s = renderInlines(e.Elements, markdown)
+			if markdown {
+				s = "> " + strings.Replace(strings.TrimRight(hardLineBreaks(s), "\n"), "\n", "\n> ", -1)
+			}
+		case *RichTextPreformatted:
+			s = renderInlines(e.Elements, false)
+			if markdown {
+				s = CodeBlock(s)
+			}
+		}
+
+		if s = strings.TrimRight(s, "\n"); s != "" {
+			_, list := e.(*RichTextList)
+			parts = append(parts, s)
+			lists = append(lists, list)
+		}
+	}
+
+	// lists continue each other, everything else is separated by a blank line in Markdown
+	var b strings.Builder
+	for i := range parts {
+		if i > 0 {
+			if markdown && !(lists[i-1] && lists[i]) {
+				b.WriteString("\n\n")
+			} else {
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString(parts[i])
+	}
+	return b.String()
+}
+
+func renderInlines(elements []RichTextInline, markdown bool) string {
+	var b strings.Builder
+
+	for _, e := range elements {
+		switch e := e.(type) {
+		case *RichTextText:
+			if markdown {
+				b.WriteString(styleMarkdown(e.Text, e.Style))
+			} else {
+				b.WriteString(e.Text)
+			}
+		case *RichTextLink:
+			text := e.Text
+			if text == "" {
+				text = e.URL
+			}
+			if !markdown {
+				b.WriteString(text)
+			} else if e.Text == "" {
+				b.WriteString("<" + e.URL + ">")
+			} else {
+				b.WriteString("[" + styleMarkdown(e.Text, e.Style) + "](" + e.URL + ")")
+			}
+		case *RichTextUser:
+			b.WriteString("@" + e.UserID)
+		case *RichTextChannel:
+			b.WriteString("#" + e.ChannelID)
+		case *RichTextUsergroup:
+			b.WriteString("@" + e.UsergroupID)
+		case *RichTextBroadcast:
+			b.WriteString("@" + e.Range)
+		case *RichTextEmoji:
+			b.WriteString(emojiText(e))
+		case *RichTextDate:
+			if e.Fallback != "" {
+				b.WriteString(e.Fallback)
+			} else {
+				b.WriteString(time.Unix(e.Timestamp, 0).UTC().Format("2006-01-02 15:04 MST"))
+			}
+		case *RichTextColor:
+			b.WriteString(e.Value)
+		}
+	}
+
+	return b.String()
+}
+
+// styleMarkdown escapes text and applies the style
+func styleMarkdown(text string, style *RichTextStyle) string {
+	if style == nil {
+		return markdownEscaper.Replace(text)
+	}
+	if style.Code {
+		return wrap(text, "`")
+	}
+	text = markdownEscaper.Replace(text)
+	if style.Strike {
+		text = wrap(text, "~~")
+	}
+	if style.Italic {
+		text = wrap(text, "_")
+	}
+	if style.Bold {
+		text = wrap(text, "**")
+	}
+	return text
+}
+
+// hardLineBreaks keeps single line breaks, which would be joined into one line in Markdown
+func hardLineBreaks(s string) string {
+	paragraphs := strings.Split(s, "\n\n")
+	for i := range paragraphs {
+		paragraphs[i] = strings.Replace(paragraphs[i], "\n", "  \n", -1)
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// emojiText returns the unicode characters of the emoji, or its :name: if unknown
+func emojiText(e *RichTextEmoji) string {
+	if e.Unicode == "" {
+		return ":" + e.Name + ":"
+	}
+
+	var b strings.Builder
+	for _, cp := range strings.Split(e.Unicode, "-") {
+		r, err := strconv.ParseInt(cp, 16, 32)
+		if err != nil {
+			return ":" + e.Name + ":"
+		}
+		b.WriteRune(rune(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,467 @@
+package slack
+
+import (
+	"encoding/json"
+)
+
+// see https://api.slack.com/reference/block-kit/blocks#rich_text
+
+type (
+	// RichTextElement is implemented by the top-level elements of a RichTextBlock
+	RichTextElement interface {
+		RichTextType() string
+	}
+
+	// RichTextInline is implemented by the inline elements of sections, quotes and preformatted text
+	RichTextInline interface {
+		InlineType() string
+	}
+
+	// RichTextBlock see https://api.slack.com/reference/block-kit/blocks#rich_text
+	// type == rich_text
+	RichTextBlock struct {
+		Type     string            `json:"type"`
+		BlockID  string            `json:"block_id,omitempty"`
+		Elements []RichTextElement `json:"elements"`
+	}
+
+	// RichTextSection is a paragraph of inline elements
+	// type == rich_text_section
+	RichTextSection struct {
+		Type     string           `json:"type"`
+		Elements []RichTextInline `json:"elements"`
+	}
+
+	// RichTextList is a bullet or ordered list, each item is a RichTextSection
+	// type == rich_text_list
+	RichTextList struct {
+		Type     string            `json:"type"`
+		Style    string            `json:"style"` // bullet or ordered
+		Elements []RichTextSection `json:"elements"`
+		Indent   int               `json:"indent,omitempty"`
+		Offset   int               `json:"offset,omitempty"`
+		Border   int               `json:"border,omitempty"`
+	}
+
+	// RichTextQuote is a quoted paragraph
+	// type == rich_text_quote
+	RichTextQuote struct {
+		Type     string           `json:"type"`
+		Elements []RichTextInline `json:"elements"`
+		Border   int              `json:"border,omitempty"`
+	}
+
+	// RichTextPreformatted is a code block
+	// type == rich_text_preformatted
+	RichTextPreformatted struct {
+		Type     string           `json:"type"`
+		Elements []RichTextInline `json:"elements"`
+		Border   int              `json:"border,omitempty"`
+	}
+
+	// RichTextStyle is the style of an inline element
+	RichTextStyle struct {
+		Bold   bool `json:"bold,omitempty"`
+		Italic bool `json:"italic,omitempty"`
+		Strike bool `json:"strike,omitempty"`
+		Code   bool `json:"code,omitempty"`
+	}
+
+	// RichTextText is plain text
+	// type == text
+	RichTextText struct {
+		Type  string         `json:"type"`
+		Text  string         `json:"text"`
+		Style *RichTextStyle `json:"style,omitempty"`
+	}
+
+	// RichTextLink is a link with an optional label
+	// type == link
+	RichTextLink struct {
+		Type   string         `json:"type"`
+		URL    string         `json:"url"`
+		Text   string         `json:"text,omitempty"`
+		Unsafe bool           `json:"unsafe,omitempty"`
+		Style  *RichTextStyle `json:"style,omitempty"`
+	}
+
+	// RichTextUser is a user mention
+	// type == user
+	RichTextUser struct {
+		Type   string         `json:"type"`
+		UserID string         `json:"user_id"`
+		Style  *RichTextStyle `json:"style,omitempty"`
+	}
+
+	// RichTextChannel is a channel mention
+	// type == channel
+	RichTextChannel struct {
+		Type      string         `json:"type"`
+		ChannelID string         `json:"channel_id"`
+		Style     *RichTextStyle `json:"style,omitempty"`
+	}
+
+	// RichTextUsergroup is a usergroup mention
+	// type == usergroup
+	RichTextUsergroup struct {
+		Type        string         `json:"type"`
+		UsergroupID string         `json:"usergroup_id"`
+		Style       *RichTextStyle `json:"style,omitempty"`
+	}
+
+	// RichTextEmoji is an emoji
+	// type == emoji
+	RichTextEmoji struct {
+		Type    string `json:"type"`
+		Name    string `json:"name"`
+		Unicode string `json:"unicode,omitempty"`
+	}
+
+	// RichTextDate is a date formatted in the reader's timezone
+	// type == date
+	RichTextDate struct {
+		Type      string `json:"type"`
+		Timestamp int64  `json:"timestamp"`
+		Format    string `json:"format"`
+		URL       string `json:"url,omitempty"`
+		Fallback  string `json:"fallback,omitempty"`
+	}
+
+	// RichTextBroadcast is a special mention, i.e. @here, @channel or @everyone
+	// type == broadcast
+	RichTextBroadcast struct {
+		Type  string `json:"type"`
+		Range string `json:"range"` // here, channel or everyone
+	}
+
+	// RichTextColor is a hex color value
+	// type == color
+	RichTextColor struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+
+	// UnknownRichTextElement holds a rich text element this package does not know about
+	UnknownRichTextElement struct {
+		Type string
+		Raw  json.RawMessage
+	}
+
+	// UnknownRichTextInline holds an inline element this package does not know about
+	UnknownRichTextInline struct {
+		Type string
+		Raw  json.RawMessage
+	}
+)
+
+// richTextTypes and richTextInlineTypes map the type of an element to its implementation
+var richTextTypes = map[string]func() RichTextElement{
+	"rich_text_section":      func() RichTextElement { return &RichTextSection{} },
+	"rich_text_list":         func() RichTextElement { return &RichTextList{} },
+	"rich_text_quote":        func() RichTextElement { return &RichTextQuote{} },
+	"rich_text_preformatted": func() RichTextElement { return &RichTextPreformatted{} },
+}
+
+var richTextInlineTypes = map[string]func() RichTextInline{
+	"text":      func() RichTextInline { return &RichTextText{} },
+	"link":      func() RichTextInline { return &RichTextLink{} },
+	"user":      func() RichTextInline { return &RichTextUser{} },
+	"channel":   func() RichTextInline { return &RichTextChannel{} },
+	"usergroup": func() RichTextInline { return &RichTextUsergroup{} },
+	"emoji":     func() RichTextInline { return &RichTextEmoji{} },
+	"date":      func() RichTextInline { return &RichTextDate{} },
+	"broadcast": func() RichTextInline { return &RichTextBroadcast{} },
+	"color":     func() RichTextInline { return &RichTextColor{} },
+}
+
+// BlockType implements Block
+func (b RichTextBlock) BlockType() string { return "rich_text" }
+
+// RichTextType implements RichTextElement
+func (e RichTextSection) RichTextType() string { return "rich_text_section" }
+
+// RichTextType implements RichTextElement
+func (e RichTextList) RichTextType() string { return "rich_text_list" }
+
+// RichTextType implements RichTextElement
+func (e RichTextQuote) RichTextType() string { return "rich_text_quote" }
+
+// RichTextType implements RichTextElement
+func (e RichTextPreformatted) RichTextType() string { return "rich_text_preformatted" }
+
+// RichTextType implements RichTextElement
+func (e UnknownRichTextElement) RichTextType() string { return e.Type }
+
+// InlineType implements RichTextInline
+func (e RichTextText) InlineType() string { return "text" }
+
+// InlineType implements RichTextInline
+func (e RichTextLink) InlineType() string { return "link" }
+
+// InlineType implements RichTextInline
+func (e RichTextUser) InlineType() string { return "user" }
+
+// InlineType implements RichTextInline
+func (e RichTextChannel) InlineType() string { return "channel" }
+
+// InlineType implements RichTextInline
+func (e RichTextUsergroup) InlineType() string { return "usergroup" }
+
+// InlineType implements RichTextInline
+func (e RichTextEmoji) InlineType() string { return "emoji" }
+
+// InlineType implements RichTextInline
+func (e RichTextDate) InlineType() string { return "date" }
+
+// InlineType implements RichTextInline
+func (e RichTextBroadcast) InlineType() string { return "broadcast" }
+
+// InlineType implements RichTextInline
+func (e RichTextColor) InlineType() string { return "color" }
+
+// InlineType implements RichTextInline
+func (e UnknownRichTextInline) InlineType() string { return e.Type }
+
+// MarshalJSON sets the block type
+func (b RichTextBlock) MarshalJSON() ([]byte, error) {
+	type alias RichTextBlock
+	b.Type = b.BlockType()
+	return json.Marshal(alias(b))
+}
+
+// MarshalJSON sets the element type
+func (e RichTextSection) MarshalJSON() ([]byte, error) {
+	type alias RichTextSection
+	e.Type = e.RichTextType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e RichTextList) MarshalJSON() ([]byte, error) {
+	type alias RichTextList
+	e.Type = e.RichTextType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e RichTextQuote) MarshalJSON() ([]byte, error) {
+	type alias RichTextQuote
+	e.Type = e.RichTextType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e RichTextPreformatted) MarshalJSON() ([]byte, error) {
+	type alias RichTextPreformatted
+	e.Type = e.RichTextType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON returns the element as it was received
+func (e UnknownRichTextElement) MarshalJSON() ([]byte, error) {
+	if e.Raw == nil {
+		return []byte("null"), nil
+	}
+	return e.Raw, nil
+}
+
+// MarshalJSON sets the element type
+func (e RichTextText) MarshalJSON() ([]byte, error) {
+	type alias RichTextText
+	e.Type = e.InlineType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e RichTextLink) MarshalJSON() ([]byte, error) {
+	type alias RichTextLink
+	e.Type = e.InlineType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e RichTextUser) MarshalJSON() ([]byte, error) {
+	type alias RichTextUser
+	e.Type = e.InlineType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e RichTextChannel) MarshalJSON() ([]byte, error) {
+	type alias RichTextChannel
+	e.Type = e.InlineType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e RichTextUsergroup) MarshalJSON() ([]byte, error) {
+	type alias RichTextUsergroup
+	e.Type = e.InlineType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e RichTextEmoji) MarshalJSON() ([]byte, error) {
+	type alias RichTextEmoji
+	e.Type = e.InlineType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e RichTextDate) MarshalJSON() ([]byte, error) {
+	type alias RichTextDate
+	e.Type = e.InlineType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e RichTextBroadcast) MarshalJSON() ([]byte, error) {
+	type alias RichTextBroadcast
+	e.Type = e.InlineType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON sets the element type
+func (e RichTextColor) MarshalJSON() ([]byte, error) {
+	type alias RichTextColor
+	e.Type = e.InlineType()
+	return json.Marshal(alias(e))
+}
+
+// MarshalJSON returns the element as it was received
+func (e UnknownRichTextInline) MarshalJSON() ([]byte, error) {
+	if e.Raw == nil {
+		return []byte("null"), nil
+	}
+	return e.Raw, nil
+}
+
+// UnmarshalJSON decodes the elements of a rich text block
+func (b *RichTextBlock) UnmarshalJSON(data []byte) error {
+	type alias RichTextBlock
+	aux := struct {
+		*alias
+		Elements []json.RawMessage `json:"elements"`
+	}{alias: (*alias)(b)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	b.Elements = nil
+	for _, raw := range aux.Elements {
+		e, err := decodeRichTextElement(raw)
+		if err != nil {
+			return err
+		}
+		b.Elements = append(b.Elements, e)
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes the inline elements of a section
+func (e *RichTextSection) UnmarshalJSON(data []byte) error {
+	type alias RichTextSection
+	aux := struct {
+		*alias
+		Elements []json.RawMessage `json:"elements"`
+	}{alias: (*alias)(e)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	elements, err := decodeRichTextInlines(aux.Elements)
+	if err != nil {
+		return err
+	}
+	e.Elements = elements
+	return nil
+}
+
+// UnmarshalJSON decodes the inline elements of a quote
+func (e *RichTextQuote) UnmarshalJSON(data []byte) error {
+	type alias RichTextQuote
+	aux := struct {
+		*alias
+		Elements []json.RawMessage `json:"elements"`
+	}{alias: (*alias)(e)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	elements, err := decodeRichTextInlines(aux.Elements)
+	if err != nil {
+		return err
+	}
+	e.Elements = elements
+	return nil
+}
+
+// UnmarshalJSON decodes the inline elements of preformatted text
+func (e *RichTextPreformatted) UnmarshalJSON(data []byte) error {
+	type alias RichTextPreformatted
+	aux := struct {
+		*alias
+		Elements []json.RawMessage `json:"elements"`
+	}{alias: (*alias)(e)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	elements, err := decodeRichTextInlines(aux.Elements)
+	if err != nil {
+		return err
+	}
+	e.Elements = elements
+	return nil
+}
+
+// decodeRichTextElement dispatches on the element type
+func decodeRichTextElement(raw json.RawMessage) (RichTextElement, error) {
+	var peek typePeek
+	if err := json.Unmarshal(raw, &peek); err != nil {
+		return nil, err
+	}
+
+	newElement, ok := richTextTypes[peek.Type]
+	if !ok {
+		return &UnknownRichTextElement{Type: peek.Type, Raw: append(json.RawMessage{}, raw...)}, nil
+	}
+
+	e := newElement()
+	if err := json.Unmarshal(raw, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// decodeRichTextInlines dispatches on the type of each inline element
+func decodeRichTextInlines(raw []json.RawMessage) ([]RichTextInline, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	elements := make([]RichTextInline, len(raw))
+	for i := range raw {
+		var peek typePeek
+		if err := json.Unmarshal(raw[i], &peek); err != nil {
+			return nil, err
+		}
+
+		newInline, ok := richTextInlineTypes[peek.Type]
+		if !ok {
+			elements[i] = &UnknownRichTextInline{Type: peek.Type, Raw: append(json.RawMessage{}, raw[i]...)}
+			continue
+		}
+
+		e := newInline()
+		if err := json.Unmarshal(raw[i], e); err != nil {
+			return nil, err
+		}
+		elements[i] = e
+	}
+	return elements, nil
+}
@@ -0,0 +1,596 @@
+package slack
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Argument types of subcommands
+const (
+	ArgString ArgType = iota
+	ArgInt
+	ArgFloat
+	ArgBool
+	ArgDuration
+	ArgUser
+	ArgChannel
+	ArgUsergroup
+)
+
+type (
+	// ArgType is the type of a positional argument or flag
+	ArgType int
+
+	// SubcommandFunc handles a subcommand, args holds the parsed and validated arguments
+	SubcommandFunc func(c *gin.Context, cmd *SlashCommand, args *CommandArgs) (*SectionBlocks, error)
+
+	// CommandRouter dispatches a slash command to its subcommands, e.g. /deploy service prod --force
+	CommandRouter struct {
+		command     string
+		description string
+		examples    []string
+		subcommands []*Subcommand
+		plainIDs    bool
+	}
+
+	// Subcommand is a subcommand with its arguments. The subcommand with an empty name
+	// handles the command if no other subcommand matches.
+	Subcommand struct {
+		Name        string
+		description string
+		examples    []string
+		args        []argSpec
+		flags       []flagSpec
+		rest        string
+		restHelp    string
+		handler     SubcommandFunc
+	}
+
+	// CommandArgs are the arguments of a subcommand. Mentions are resolved to their IDs.
+	CommandArgs struct {
+		Subcommand string
+		Rest       []string
+		Entities   *MessageEntities // all mentions and links in the command text
+		values     map[string]string
+	}
+
+	// UsageError is returned if the command text does not match the subcommand's arguments
+	UsageError struct {
+		Command    string
+		Subcommand string
+		Usage      string
+		Message    string
+	}
+
+	argSpec struct {
+		name     string
+		typ      ArgType
+		optional bool
+		help     string
+	}
+
+	flagSpec struct {
+		name  string
+		typ   ArgType
+		value string // default
+		help  string
+	}
+)
+
+var (
+	userArgPattern      = regexp.MustCompile(`^<@([UW][A-Z0-9]+)(?:\|[^>]*)?>$`)
+	channelArgPattern   = regexp.MustCompile(`^<#([CG][A-Z0-9]+)(?:\|[^>]*)?>$`)
+	usergroupArgPattern = regexp.MustCompile(`^<!subteam\^([A-Z0-9]+)(?:\|[^>]*)?>$`)
+
+	// plain IDs are only accepted if the router allows them, see AcceptPlainIDs
+	plainUserIDPattern      = regexp.MustCompile(`^[UW][A-Z0-9]{8,}$`)
+	plainChannelIDPattern   = regexp.MustCompile(`^[CGD][A-Z0-9]{8,}$`)
+	plainUsergroupIDPattern = regexp.MustCompile(`^S[A-Z0-9]{8,}$`)
+)
+
+// NewCommandRouter creates a router for a slash command. Call Register to start handling the command.
+func NewCommandRouter(command string) *CommandRouter {
+	return &CommandRouter{command: command}
+}
+
+// Register registers the router as handler of its command, and its help. The help is
+// built when it is shown, so subcommands can still be added after Register.
+func (r *CommandRouter) Register() {
+	RegisterSlashCmdHandler(r.command, r.Handle)
+	registerSlashCmdHelpFunc(r.command, r.Help)
+}
+
+// Describe sets the description of the command shown in its help
+func (r *CommandRouter) Describe(text string) *CommandRouter {
+	r.description = text
+	return r
+}
+
+// Example adds examples shown in the help of the command
+func (r *CommandRouter) Example(examples ...string) *CommandRouter {
+	r.examples = append(r.examples, examples...)
+	return r
+}
+
+// AcceptPlainIDs allows users, channels and user groups to be given as plain IDs like U0123ABCD,
+// in addition to mentions like <@U0123ABCD>. Only IDs with Slack's prefixes are accepted.
+func (r *CommandRouter) AcceptPlainIDs() *CommandRouter {
+	r.plainIDs = true
+	return r
+}
+
+// Subcommand adds a subcommand. Names can have several words, e.g. "config set".
+func (r *CommandRouter) Subcommand(name string, h SubcommandFunc) *Subcommand {
+	sub := &Subcommand{Name: strings.ToLower(strings.Join(strings.Fields(name), " ")), handler: h}
+	r.subcommands = append(r.subcommands, sub)
+
+	// longest names first, so that "config set" wins over "config"
+	sort.SliceStable(r.subcommands, func(i, j int) bool {
+		return len(strings.Fields(r.subcommands[i].Name)) > len(strings.Fields(r.subcommands[j].Name))
+	})
+	return sub
+}
+
+// Arg adds a required positional argument
+func (s *Subcommand) Arg(name string, t ArgType) *Subcommand {
+	s.args = append(s.args, argSpec{name: name, typ: t})
+	return s
+}
+
+// OptionalArg adds an optional positional argument, it must follow the required arguments
+func (s *Subcommand) OptionalArg(name string, t ArgType) *Subcommand {
+	s.args = append(s.args, argSpec{name: name, typ: t, optional: true})
+	return s
+}
+
+// RestArg collects all remaining arguments into CommandArgs.Rest
+func (s *Subcommand) RestArg(name string) *Subcommand {
+	s.rest = name
+	return s
+}
+
+// Flag adds a flag, e.g. --force or --region=eu. Bool flags don't take a value.
+func (s *Subcommand) Flag(name string, t ArgType, defaultValue string) *Subcommand {
+	s.flags = append(s.flags, flagSpec{name: name, typ: t, value: defaultValue})
+	return s
+}
+
+// Describe sets the description of the subcommand shown in the help
+func (s *Subcommand) Describe(text string) *Subcommand {
+	s.description = text
+	return s
+}
+
+// Example adds examples shown in the help of the subcommand
+func (s *Subcommand) Example(examples ...string) *Subcommand {
+	s.examples = append(s.examples, examples...)
+	return s
+}
+
+// Explain sets the description of an argument or flag shown in the help
+func (s *Subcommand) Explain(name, text string) *Subcommand {
+	for i := range s.args {
+		if s.args[i].name == name {
+			s.args[i].help = text
+		}
+	}
+	for i := range s.flags {
+		if s.flags[i].name == name {
+			s.flags[i].help = text
+		}
+	}
+	if s.rest == name {
+		s.restHelp = text
+	}
+	return s
+}
+
+// Usage returns a usage line, e.g. /deploy service <name> [<env>] [--force]
+func (s *Subcommand) Usage(command string) string {
+	parts := []string{command}
+	if s.Name != "" {
+		parts = append(parts, s.Name)
+	}
+	for _, a := range s.args {
+		if a.optional {
+			parts = append(parts, "[<"+a.name+">]")
+		} else {
+			parts = append(parts, "<"+a.name+">")
+		}
+	}
+	if s.rest != "" {
+		parts = append(parts, "[<"+s.rest+">...]")
+	}
+	for _, f := range s.flags {
+		if f.typ == ArgBool {
+			parts = append(parts, "[--"+f.name+"]")
+		} else {
+			parts = append(parts, "[--"+f.name+"=<"+argTypeName(f.typ)+">]")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Handle implements SlashCommandFunc. "/cmd help [subcommand]" and usage errors are answered
+// with the help of the command.
+func (r *CommandRouter) Handle(c *gin.Context, cmd *SlashCommand) (*SectionBlocks, error) {
+	if topic, ok := r.helpRequested(cmd.Txt); ok {
+		return &SectionBlocks{Blocks: r.Help().SubcommandBlocks(topic)}, nil
+	}
+
+	sub, args, err := r.Parse(cmd)
+	if err != nil {
+		if ue, ok := err.(*UsageError); ok {
+			return UsageErrorBlocks(r.Help(), ue), nil
+		}
+		return nil, err
+	}
+	return sub.handler(c, cmd, args)
+}
+
+// helpRequested returns true for "/cmd help [subcommand]", or if the command needs a subcommand but has none
+func (r *CommandRouter) helpRequested(text string) (string, bool) {
+	tokens, err := Tokenize(text)
+	if err != nil {
+		return "", false
+	}
+	if len(tokens) == 0 {
+		for _, s := range r.subcommands {
+			if s.Name == "" {
+				return "", false
+			}
+		}
+		return "", true
+	}
+	if strings.ToLower(tokens[0]) != "help" {
+		return "", false
+	}
+	for _, s := range r.subcommands {
+		if s.Name == "help" {
+			return "", false
+		}
+	}
+	return strings.ToLower(strings.Join(tokens[1:], " ")), true
+}
+
+// Parse finds the subcommand and parses its arguments
+func (r *CommandRouter) Parse(cmd *SlashCommand) (*Subcommand, *CommandArgs, error) {
+	tokens, err := Tokenize(cmd.Txt)
+	if err != nil {
+		return nil, nil, r.usageError(nil, err.Error())
+	}
+
+	var sub *Subcommand
+	for _, s := range r.subcommands {
+		if n := matchSubcommand(s.Name, tokens); n >= 0 {
+			sub = s
+			tokens = tokens[n:]
+			break
+		}
+	}
+	if sub == nil {
+		if len(tokens) == 0 {
+			return nil, nil, r.usageError(nil, "missing subcommand")
+		}
+		return nil, nil, r.usageError(nil, fmt.Sprintf("unknown subcommand '%s'", tokens[0]))
+	}
+
+	args, err := sub.parse(tokens, r.plainIDs)
+	if err != nil {
+		return nil, nil, r.usageError(sub, err.Error())
+	}
+	args.Subcommand = sub.Name
+	args.Entities = ParseEntities(cmd.Txt)
+
+	return sub, args, nil
+}
+
+func (r *CommandRouter) usageError(sub *Subcommand, msg string) *UsageError {
+	ue := &UsageError{Command: r.command, Message: msg}
+	if sub != nil {
+		ue.Subcommand = sub.Name
+		ue.Usage = sub.Usage(r.command)
+		return ue
+	}
+
+	usage := make([]string, len(r.subcommands))
+	for i, s := range r.subcommands {
+		usage[i] = s.Usage(r.command)
+	}
+	sort.Strings(usage)
+	ue.Usage = strings.Join(usage, "\n")
+	return ue
+}
+
+func (s *Subcommand) parse(tokens []string, plainIDs bool) (*CommandArgs, error) {
+	args := CommandArgs{values: make(map[string]string)}
+	for _, f := range s.flags {
+		if f.value != "" {
+			args.values[f.name] = f.value
+		}
+	}
+
+	var positional []string
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+
+		if t == "--" {
+			positional = append(positional, tokens[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(t, "--") || len(t) == 2 {
+			positional = append(positional, t)
+			continue
+		}
+
+		name, value := t[2:], ""
+		hasValue := false
+		if j := strings.Index(name, "="); j >= 0 {
+			name, value, hasValue = name[:j], name[j+1:], true
+		}
+
+		flag := s.flag(name)
+		if flag == nil {
+			return nil, fmt.Errorf("unknown flag --%s", name)
+		}
+		if !hasValue {
+			if flag.typ == ArgBool {
+				value = "true"
+			} else if i+1 < len(tokens) {
+				i++
+				value = tokens[i]
+			} else {
+				return nil, fmt.Errorf("missing value of --%s", name)
+			}
+		}
+
+		v, err := normalizeArg(value, flag.typ, plainIDs)
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %v", name, err)
+		}
+		args.values[name] = v
+	}
+
+	for i, a := range s.args {
+		if i >= len(positional) {
+			if !a.optional {
+				return nil, fmt.Errorf("missing <%s>", a.name)
+			}
+			continue
+		}
+		v, err := normalizeArg(positional[i], a.typ, plainIDs)
+		if err != nil {
+			return nil, fmt.Errorf("<%s>: %v", a.name, err)
+		}
+		args.values[a.name] = v
+	}
+
+	if len(positional) > len(s.args) {
+		if s.rest == "" {
+			return nil, fmt.Errorf("unexpected argument '%s'", positional[len(s.args)])
+		}
+		args.Rest = positional[len(s.args):]
+	}
+
+	return &args, nil
+}
+
+func (s *Subcommand) flag(name string) *flagSpec {
+	for i := range s.flags {
+		if s.flags[i].name == name {
+			return &s.flags[i]
+		}
+	}
+	return nil
+}
+
+// Has returns true if the argument or flag was given or has a default
+func (a *CommandArgs) Has(name string) bool {
+	_, ok := a.values[name]
+	return ok
+}
+
+// String returns an argument or flag
+func (a *CommandArgs) String(name string) string {
+	return a.values[name]
+}
+
+// Int returns an ArgInt argument or flag
+func (a *CommandArgs) Int(name string) int {
+	i, _ := strconv.Atoi(a.values[name])
+	return i
+}
+
+// Float returns an ArgFloat argument or flag
+func (a *CommandArgs) Float(name string) float64 {
+	f, _ := strconv.ParseFloat(a.values[name], 64)
+	return f
+}
+
+// Bool returns an ArgBool argument or flag
+func (a *CommandArgs) Bool(name string) bool {
+	b, _ := strconv.ParseBool(a.values[name])
+	return b
+}
+
+// Duration returns an ArgDuration argument or flag
+func (a *CommandArgs) Duration(name string) time.Duration {
+	d, _ := time.ParseDuration(a.values[name])
+	return d
+}
+
+// User returns the user ID of an ArgUser argument or flag
+func (a *CommandArgs) User(name string) string {
+	return a.values[name]
+}
+
+// Channel returns the channel ID of an ArgChannel argument or flag
+func (a *CommandArgs) Channel(name string) string {
+	return a.values[name]
+}
+
+// Usergroup returns the usergroup ID of an ArgUsergroup argument or flag
+func (a *CommandArgs) Usergroup(name string) string {
+	return a.values[name]
+}
+
+func (e *UsageError) Error() string {
+	return fmt.Sprintf("%s: %s", strings.TrimSpace(e.Command+" "+e.Subcommand), e.Message)
+}
+
+// Tokenize splits the text of a command into arguments. Arguments are separated by spaces
+// and can be quoted with single, double or typographic quotes. Quotes only start a quoted argument
+// at the beginning of a token, so words like can't stay as they are. Mentions and links like
+// <@U123|name> are always one argument.
+func Tokenize(text string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inToken := false
+	var quote rune
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case quote != 0:
+			if r == quote || quote == '“' && r == '”' || quote == '‘' && r == '’' {
+				quote = 0
+			} else if r == '\\' && i+1 < len(runes) {
+				i++
+				current.WriteRune(runes[i])
+			} else {
+				current.WriteRune(r)
+			}
+		case !inToken && (r == '"' || r == '\'' || r == '“' || r == '‘'):
+			quote = r
+			inToken = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			current.WriteRune(runes[i])
+			inToken = true
+		case r == '<':
+			end := i
+			for end < len(runes) && runes[end] != '>' {
+				end++
+			}
+			if end == len(runes) {
+				current.WriteRune(r)
+			} else {
+				current.WriteString(string(runes[i : end+1]))
+				i = end
+			}
+			inToken = true
+		case r == ' ' || r == '\t' || r == '\n' || r == ' ':
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote %c", quote)
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+
+	// Slack escapes &, < and > outside of mentions and links
+	for i := range tokens {
+		if !entityPattern.MatchString(tokens[i]) {
+			tokens[i] = Unescape(tokens[i])
+		}
+	}
+	return tokens, nil
+}
+
+// matchSubcommand returns the number of tokens matching the name, or -1
+func matchSubcommand(name string, tokens []string) int {
+	words := strings.Fields(name)
+	if len(words) > len(tokens) {
+		return -1
+	}
+	for i, w := range words {
+		if strings.ToLower(tokens[i]) != w {
+			return -1
+		}
+	}
+	return len(words)
+}
+
+// normalizeArg validates an argument and resolves mentions to IDs. Plain IDs are accepted if plainIDs is set.
+func normalizeArg(value string, t ArgType, plainIDs bool) (string, error) {
+	switch t {
+	case ArgInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return "", fmt.Errorf("'%s' is not a number", value)
+		}
+	case ArgFloat:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", fmt.Errorf("'%s' is not a number", value)
+		}
+	case ArgBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("'%s' is not true or false", value)
+		}
+		return strconv.FormatBool(b), nil
+	case ArgDuration:
+		if _, err := time.ParseDuration(value); err != nil {
+			return "", fmt.Errorf("'%s' is not a duration, e.g. 90s or 1h30m", value)
+		}
+	case ArgUser:
+		return mentionID(value, userArgPattern, plainIDPattern(plainIDs, plainUserIDPattern), "a user")
+	case ArgChannel:
+		return mentionID(value, channelArgPattern, plainIDPattern(plainIDs, plainChannelIDPattern), "a channel")
+	case ArgUsergroup:
+		return mentionID(value, usergroupArgPattern, plainIDPattern(plainIDs, plainUsergroupIDPattern), "a user group")
+	}
+	return value, nil
+}
+
+func mentionID(value string, pattern, plain *regexp.Regexp, what string) (string, error) {
+	if m := pattern.FindStringSubmatch(value); m != nil {
+		return m[1], nil
+	}
+	if plain != nil && plain.MatchString(value) {
+		return value, nil
+	}
+	return "", fmt.Errorf("'%s' is not %s", value, what)
+}
+
+func plainIDPattern(allowed bool, pattern *regexp.Regexp) *regexp.Regexp {
+	if allowed {
+		return pattern
+	}
+	return nil
+}
+
+func argTypeName(t ArgType) string {
+	switch t {
+	case ArgInt, ArgFloat:
+		return "number"
+	case ArgBool:
+		return "true|false"
+	case ArgDuration:
+		return "duration"
+	case ArgUser:
+		return "@user"
+	case ArgChannel:
+		return "#channel"
+	case ArgUsergroup:
+		return "@group"
+	}
+	return "value"
+}
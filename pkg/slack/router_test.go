@@ -0,0 +1,80 @@
+package slack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    []string
+		wantErr bool
+	}{
+		{"empty", "", nil, false},
+		{"words", "deploy  api\tprod", []string{"deploy", "api", "prod"}, false},
+		{"double quotes", `say "hello world"`, []string{"say", "hello world"}, false},
+		{"single quotes", "say 'hello world'", []string{"say", "hello world"}, false},
+		{"typographic quotes", "say “hello world”", []string{"say", "hello world"}, false},
+		{"apostrophe", "remind me I can't", []string{"remind", "me", "I", "can't"}, false},
+		{"apostrophes", "it's Bob's turn", []string{"it's", "Bob's", "turn"}, false},
+		{"quote inside a word", `size=5"`, []string{`size=5"`}, false},
+		{"escaped quote", `say \"hi`, []string{"say", `"hi`}, false},
+		{"escape in quotes", `"a \" b"`, []string{`a " b`}, false},
+		{"mention", "add <@U123|jane doe> now", []string{"add", "<@U123|jane doe>", "now"}, false},
+		{"unescape", "a &lt; b", []string{"a", "<", "b"}, false},
+		{"unterminated quote", `say "hello`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Tokenize(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Tokenize(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeArg(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		typ      ArgType
+		plainIDs bool
+		want     string
+		wantErr  bool
+	}{
+		{"user mention", "<@U0123ABCD|jane>", ArgUser, false, "U0123ABCD", false},
+		{"enterprise user mention", "<@W0123ABCD>", ArgUser, false, "W0123ABCD", false},
+		{"channel mention", "<#C0123ABCD|general>", ArgChannel, false, "C0123ABCD", false},
+		{"usergroup mention", "<!subteam^S0123ABCD|@ops>", ArgUsergroup, false, "S0123ABCD", false},
+		{"plain user ID not allowed", "U0123ABCD", ArgUser, false, "", true},
+		{"plain user ID", "U0123ABCD", ArgUser, true, "U0123ABCD", false},
+		{"plain DM channel ID", "D0123ABCD", ArgChannel, true, "D0123ABCD", false},
+		{"plain channel ID as user", "C0123ABCD", ArgUser, true, "", true},
+		{"word as user", "PRODUCTION", ArgUser, true, "", true},
+		{"word as channel", "STAGING01", ArgChannel, true, "", true},
+		{"too short", "U123", ArgUser, true, "", true},
+		{"int", "42", ArgInt, false, "42", false},
+		{"not an int", "x", ArgInt, false, "", true},
+		{"bool", "yes", ArgBool, false, "", true},
+		{"duration", "1h30m", ArgDuration, false, "1h30m", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeArg(tt.value, tt.typ, tt.plainIDs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeArg(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeArg(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package slack
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// see https://api.slack.com/authentication/verifying-requests-from-slack
+
+const (
+	// maxRequestAge is the maximum difference between the request timestamp and the local clock
+	maxRequestAge = 5 * time.Minute
+	// maxRequestBody limits the size of signed requests
+	maxRequestBody = 1 << 20
+
+	signatureVersion = "v0"
+)
+
+// VerifyRequest checks the signature of a request from Slack with SLACK_SIGNING_SECRET.
+// The body is restored, it can be bound after the verification.
+func VerifyRequest(c *gin.Context) error {
+	secret := os.Getenv(SlackSigningSecret)
+	if secret == "" {
+		return fmt.Errorf("%s is not set", SlackSigningSecret)
+	}
+	return verifySignature(c.Request, secret, time.Now())
+}
+
+// verifySignature checks the X-Slack-Signature header against the HMAC of the timestamp and body
+func verifySignature(r *http.Request, secret string, now time.Time) error {
+	ts := r.Header.Get("X-Slack-Request-Timestamp")
+	signature := r.Header.Get("X-Slack-Signature")
+	if ts == "" || signature == "" {
+		return fmt.Errorf("unsigned request")
+	}
+
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid request timestamp '%s'", ts)
+	}
+	if age := now.Sub(time.Unix(sec, 0)); age > maxRequestAge || age < -maxRequestAge {
+		return fmt.Errorf("request timestamp '%s' is outside the allowed window", ts)
+	}
+
+	if r.Body == nil {
+		return fmt.Errorf("empty request")
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, maxRequestBody))
+	r.Body.Close()
+	if err != nil {
+		return err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signatureVersion + ":" + ts + ":"))
+	mac.Write(body)
+	expected := signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return fmt.Errorf("invalid request signature")
+	}
+	return nil
+}
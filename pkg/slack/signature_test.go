@@ -0,0 +1,76 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signedRequest(secret, body string, ts int64) *http.Request {
+	r, _ := http.NewRequest("POST", "/events", strings.NewReader(body))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + strconv.FormatInt(ts, 10) + ":" + body))
+	r.Header.Set("X-Slack-Request-Timestamp", strconv.FormatInt(ts, 10))
+	r.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return r
+}
+
+func TestVerifySignature(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	body := `{"type":"event_callback","team_id":"T1"}`
+
+	tests := []struct {
+		name    string
+		req     func() *http.Request
+		wantErr bool
+	}{
+		{"valid", func() *http.Request { return signedRequest("secret", body, now.Unix()) }, false},
+		{"within window", func() *http.Request { return signedRequest("secret", body, now.Unix()-299) }, false},
+		{"too old", func() *http.Request { return signedRequest("secret", body, now.Unix()-301) }, true},
+		{"in the future", func() *http.Request { return signedRequest("secret", body, now.Unix()+301) }, true},
+		{"wrong secret", func() *http.Request { return signedRequest("other", body, now.Unix()) }, true},
+		{"tampered body", func() *http.Request {
+			r := signedRequest("secret", body, now.Unix())
+			r.Body = ioutil.NopCloser(strings.NewReader(`{"type":"event_callback","team_id":"T2"}`))
+			return r
+		}, true},
+		{"unsigned", func() *http.Request {
+			r, _ := http.NewRequest("POST", "/events", strings.NewReader(body))
+			return r
+		}, true},
+		{"malformed timestamp", func() *http.Request {
+			r := signedRequest("secret", body, now.Unix())
+			r.Header.Set("X-Slack-Request-Timestamp", "yesterday")
+			return r
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifySignature(tt.req(), "secret", now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifySignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifySignatureRestoresBody(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	body := `{"type":"url_verification","challenge":"abc"}`
+
+	r := signedRequest("secret", body, now.Unix())
+	if err := verifySignature(r, "secret", now); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := ioutil.ReadAll(r.Body)
+	if string(b) != body {
+		t.Errorf("body = %q, want %q", b, body)
+	}
+}
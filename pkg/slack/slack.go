@@ -17,6 +17,8 @@ const (
 	SlackOAuthToken string = "SLACK_OAUTH_TOKEN"
 	// SlackVerificationToken is a secret token used to verify requests from Slack
 	SlackVerificationToken string = "SLACK_VERIFICATION_TOKEN"
+	// SlackSigningSecret is used to verify the signature of requests from Slack
+	SlackSigningSecret string = "SLACK_SIGNING_SECRET"
 	// SlackResponseTypeChannel is used to send messages to channels that are visible to evryone
 	SlackResponseTypeChannel string = "in_channel"
 	// SlackResponseTypeEphemeral is used to send a message to a channel that is only visible to the current user
@@ -29,7 +31,14 @@ func init() {
 	completeActionLookup = make(map[string]CompleteActionFunc)
 	// initialize the slash-command lookup table
 	slashCommandLookup = make(map[string]SlashCommandFunc)
+	slashCommandHelp = make(map[string]func() *CommandHelp)
 	RegisterDefaultSlashCmdHandler(unknownCommandHandler)
+	// initialize the middleware lookup table
+	handlerMiddleware = make(map[string][]Middleware)
+	// initialize the event lookup table
+	eventLookup = make(map[string]EventHandlerFunc)
+	RegisterEventHandler("app_uninstalled", appUninstalledHandler)
+	RegisterEventHandler("tokens_revoked", tokensRevokedHandler)
 }
 
 // Timestamp returns the seconds part of a Slack timestamp
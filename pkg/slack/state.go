@@ -0,0 +1,233 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// see https://api.slack.com/reference/interaction-payloads/views#view_submission_fields
+
+var (
+	// ErrMissingValue is returned if the state has no value for an input, e.g. an optional input left empty
+	ErrMissingValue = errors.New("missing value")
+	// ErrInvalidValue is returned if a value can not be converted to the requested type
+	ErrInvalidValue = errors.New("invalid value")
+)
+
+// Get returns the value of the input identified by blockID and actionID
+func (s *StateValues) Get(blockID, actionID string) (*ValueObject, error) {
+	if s == nil {
+		return nil, fmt.Errorf("%w: no state for block '%s', action '%s'", ErrMissingValue, blockID, actionID)
+	}
+	actions, ok := s.Values[blockID]
+	if !ok {
+		return nil, fmt.Errorf("%w: no block '%s'", ErrMissingValue, blockID)
+	}
+	v, ok := actions[actionID]
+	if !ok {
+		return nil, fmt.Errorf("%w: no action '%s' in block '%s'", ErrMissingValue, actionID, blockID)
+	}
+	return &v, nil
+}
+
+// String returns the value of a plain_text_input, email_text_input, url_text_input or number_input
+func (s *StateValues) String(blockID, actionID string) (string, error) {
+	v, err := s.Get(blockID, actionID)
+	if err != nil {
+		return "", err
+	}
+	if v.Value == nil {
+		return "", missing(blockID, actionID)
+	}
+	return *v.Value, nil
+}
+
+// Int returns the value of a number_input as integer
+func (s *StateValues) Int(blockID, actionID string) (int64, error) {
+	v, err := s.String(blockID, actionID)
+	if err != nil {
+		return 0, err
+	}
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, invalid(blockID, actionID, v)
+	}
+	return i, nil
+}
+
+// Number returns the value of a number_input
+func (s *StateValues) Number(blockID, actionID string) (float64, error) {
+	v, err := s.String(blockID, actionID)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, invalid(blockID, actionID, v)
+	}
+	return f, nil
+}
+
+// Option returns the selected option of a static_select, external_select, radio_buttons or overflow
+func (s *StateValues) Option(blockID, actionID string) (*OptionsObject, error) {
+	v, err := s.Get(blockID, actionID)
+	if err != nil {
+		return nil, err
+	}
+	if v.Option == nil {
+		return nil, missing(blockID, actionID)
+	}
+	return v.Option, nil
+}
+
+// Options returns the selected options of checkboxes or a multi_static_select or multi_external_select.
+// An empty selection returns nil, not an error.
+func (s *StateValues) Options(blockID, actionID string) ([]OptionsObject, error) {
+	v, err := s.Get(blockID, actionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(v.Options) == 0 {
+		return nil, nil
+	}
+	return v.Options, nil
+}
+
+// Date returns the selected date of a datepicker
+func (s *StateValues) Date(blockID, actionID string) (time.Time, error) {
+	v, err := s.Get(blockID, actionID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if v.SelectedDate == "" {
+		return time.Time{}, missing(blockID, actionID)
+	}
+	t, err := time.Parse("2006-01-02", v.SelectedDate)
+	if err != nil {
+		return time.Time{}, invalid(blockID, actionID, v.SelectedDate)
+	}
+	return t, nil
+}
+
+// Time returns the selected time of a timepicker as hour and minute
+func (s *StateValues) Time(blockID, actionID string) (int, int, error) {
+	v, err := s.Get(blockID, actionID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if v.SelectedTime == "" {
+		return 0, 0, missing(blockID, actionID)
+	}
+	t, err := time.Parse("15:04", v.SelectedTime)
+	if err != nil {
+		return 0, 0, invalid(blockID, actionID, v.SelectedTime)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// DateTime returns the selected date and time of a datetimepicker
+func (s *StateValues) DateTime(blockID, actionID string) (time.Time, error) {
+	v, err := s.Get(blockID, actionID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if v.SelectedDateTime == 0 {
+		return time.Time{}, missing(blockID, actionID)
+	}
+	return time.Unix(v.SelectedDateTime, 0), nil
+}
+
+// User returns the selected user of a users_select
+func (s *StateValues) User(blockID, actionID string) (string, error) {
+	v, err := s.Get(blockID, actionID)
+	if err != nil {
+		return "", err
+	}
+	if v.SelectedUser == "" {
+		return "", missing(blockID, actionID)
+	}
+	return v.SelectedUser, nil
+}
+
+// Users returns the selected users of a multi_users_select. An empty selection returns nil, not an error.
+func (s *StateValues) Users(blockID, actionID string) ([]string, error) {
+	v, err := s.Get(blockID, actionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(v.SelectedUsers) == 0 {
+		return nil, nil
+	}
+	return v.SelectedUsers, nil
+}
+
+// Conversation returns the selected conversation of a conversations_select
+func (s *StateValues) Conversation(blockID, actionID string) (string, error) {
+	v, err := s.Get(blockID, actionID)
+	if err != nil {
+		return "", err
+	}
+	if v.SelectedConversation == "" {
+		return "", missing(blockID, actionID)
+	}
+	return v.SelectedConversation, nil
+}
+
+// Conversations returns the selected conversations of a multi_conversations_select.
+// An empty selection returns nil, not an error.
+func (s *StateValues) Conversations(blockID, actionID string) ([]string, error) {
+	v, err := s.Get(blockID, actionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(v.SelectedConversations) == 0 {
+		return nil, nil
+	}
+	return v.SelectedConversations, nil
+}
+
+// Channel returns the selected channel of a channels_select
+func (s *StateValues) Channel(blockID, actionID string) (string, error) {
+	v, err := s.Get(blockID, actionID)
+	if err != nil {
+		return "", err
+	}
+	if v.SelectedChannel == "" {
+		return "", missing(blockID, actionID)
+	}
+	return v.SelectedChannel, nil
+}
+
+// Channels returns the selected channels of a multi_channels_select. An empty selection returns nil, not an error.
+func (s *StateValues) Channels(blockID, actionID string) ([]string, error) {
+	v, err := s.Get(blockID, actionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(v.SelectedChannels) == 0 {
+		return nil, nil
+	}
+	return v.SelectedChannels, nil
+}
+
+// RichText returns the value of a rich_text_input
+func (s *StateValues) RichText(blockID, actionID string) (*RichTextBlock, error) {
+	v, err := s.Get(blockID, actionID)
+	if err != nil {
+		return nil, err
+	}
+	if v.RichTextValue == nil {
+		return nil, missing(blockID, actionID)
+	}
+	return v.RichTextValue, nil
+}
+
+func missing(blockID, actionID string) error {
+	return fmt.Errorf("%w: block '%s', action '%s'", ErrMissingValue, blockID, actionID)
+}
+
+func invalid(blockID, actionID, value string) error {
+	return fmt.Errorf("%w: '%s' in block '%s', action '%s'", ErrInvalidValue, value, blockID, actionID)
+}
@@ -0,0 +1,67 @@
+package slack
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testState = `{"values": {
+	"count": {"int": {"type": "number_input", "value": "42"}, "decimal": {"type": "number_input", "value": "4.2"}, "text": {"type": "number_input", "value": "many"}, "empty": {"type": "number_input"}},
+	"when": {"date": {"type": "datepicker", "selected_date": "2020-10-05"}, "bad_date": {"type": "datepicker", "selected_date": "05.10.2020"}, "no_date": {"type": "datepicker"},
+		"time": {"type": "timepicker", "selected_time": "09:30"}, "bad_time": {"type": "timepicker", "selected_time": "9.30 am"}, "no_time": {"type": "timepicker"}},
+	"pick": {"one": {"type": "static_select", "selected_option": {"text": {"type": "plain_text", "text": "A"}, "value": "a"}}, "none": {"type": "static_select", "selected_option": null},
+		"many": {"type": "multi_static_select", "selected_options": []}, "users": {"type": "multi_users_select", "selected_users": []}, "channels": {"type": "multi_channels_select"}}
+}}`
+
+func TestStateValues(t *testing.T) {
+	var s StateValues
+	if err := json.Unmarshal([]byte(testState), &s); err != nil {
+		t.Fatal(err)
+	}
+
+	hourMinute := func(h, m int, err error) (interface{}, error) { return [2]int{h, m}, err }
+
+	tests := []struct {
+		name    string
+		get     func() (interface{}, error)
+		want    interface{}
+		wantErr error
+	}{
+		{"int", func() (interface{}, error) { return s.Int("count", "int") }, int64(42), nil},
+		{"decimal as int", func() (interface{}, error) { return s.Int("count", "decimal") }, int64(0), ErrInvalidValue},
+		{"text as int", func() (interface{}, error) { return s.Int("count", "text") }, int64(0), ErrInvalidValue},
+		{"number", func() (interface{}, error) { return s.Number("count", "decimal") }, 4.2, nil},
+		{"text as number", func() (interface{}, error) { return s.Number("count", "text") }, 0.0, ErrInvalidValue},
+		{"empty number", func() (interface{}, error) { return s.Number("count", "empty") }, 0.0, ErrMissingValue},
+		{"date", func() (interface{}, error) { return s.Date("when", "date") }, time.Date(2020, 10, 5, 0, 0, 0, 0, time.UTC), nil},
+		{"malformed date", func() (interface{}, error) { return s.Date("when", "bad_date") }, time.Time{}, ErrInvalidValue},
+		{"no date", func() (interface{}, error) { return s.Date("when", "no_date") }, time.Time{}, ErrMissingValue},
+		{"time", func() (interface{}, error) { return hourMinute(s.Time("when", "time")) }, [2]int{9, 30}, nil},
+		{"malformed time", func() (interface{}, error) { return hourMinute(s.Time("when", "bad_time")) }, [2]int{0, 0}, ErrInvalidValue},
+		{"no time", func() (interface{}, error) { return hourMinute(s.Time("when", "no_time")) }, [2]int{0, 0}, ErrMissingValue},
+		{"missing block", func() (interface{}, error) { return s.String("other", "int") }, "", ErrMissingValue},
+		{"missing action", func() (interface{}, error) { return s.String("count", "other") }, "", ErrMissingValue},
+		{"no state", func() (interface{}, error) { return (*StateValues)(nil).String("count", "int") }, "", ErrMissingValue},
+		{"option", func() (interface{}, error) { return s.Option("pick", "one") }, &OptionsObject{Text: TextObject{Type: "plain_text", Text: "A"}, Value: "a"}, nil},
+		{"no option", func() (interface{}, error) { return s.Option("pick", "none") }, (*OptionsObject)(nil), ErrMissingValue},
+		{"empty multi-select", func() (interface{}, error) { return s.Options("pick", "many") }, []OptionsObject(nil), nil},
+		{"empty users", func() (interface{}, error) { return s.Users("pick", "users") }, []string(nil), nil},
+		{"empty channels", func() (interface{}, error) { return s.Channels("pick", "channels") }, []string(nil), nil},
+		{"multi-select of missing action", func() (interface{}, error) { return s.Options("pick", "other") }, []OptionsObject(nil), ErrMissingValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get()
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("value = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+type (
+	// TemplateFS reads template files. It is implemented by embed.FS and by TemplateDir.
+	TemplateFS interface {
+		ReadFile(name string) ([]byte, error)
+	}
+
+	// TemplateDir reads template files from a directory
+	TemplateDir string
+
+	// Templates renders views and messages from text/template files. Templates are JSON,
+	// e.g. as exported from Block Kit Builder, or YAML if the file name ends in .yaml or .yml.
+	// Parsed templates are cached.
+	Templates struct {
+		fs    TemplateFS
+		funcs template.FuncMap
+		mu    sync.RWMutex
+		cache map[string]*template.Template
+	}
+)
+
+// defaultTemplateFuncs are available in all templates
+var defaultTemplateFuncs = template.FuncMap{
+	// json encodes a value, use it to insert strings into JSON templates: "text": {{ json .Name }}
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"escape":   Escape,
+	"markdown": MarkdownToMrkdwn,
+	"user":     MentionUser,
+	"channel":  MentionChannel,
+}
+
+// ReadFile implements TemplateFS
+func (d TemplateDir) ReadFile(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(string(d), filepath.FromSlash(name)))
+}
+
+// NewTemplates creates a template set reading from fs
+func NewTemplates(fs TemplateFS) *Templates {
+	return &Templates{
+		fs:    fs,
+		funcs: template.FuncMap{},
+		cache: make(map[string]*template.Template),
+	}
+}
+
+// Funcs adds functions to the templates. It must be called before templates are loaded.
+func (t *Templates) Funcs(funcs template.FuncMap) *Templates {
+	for k, v := range funcs {
+		t.funcs[k] = v
+	}
+	return t
+}
+
+// Load parses a template and renders it with the sample data. The result must decode into
+// a view or message that passes Validate. Call Load at startup to catch errors early.
+func (t *Templates) Load(name string, sample interface{}) error {
+	tmpl, err := t.parse(name)
+	if err != nil {
+		return err
+	}
+
+	data, err := t.execute(name, tmpl, sample)
+	if err != nil {
+		return err
+	}
+
+	var peek typePeek
+	if err := json.Unmarshal(data, &peek); err != nil {
+		return fmt.Errorf("template %s: %w", name, err)
+	}
+
+	var violations Violations
+	if peek.Type == "modal" || peek.Type == "home" {
+		var view ViewElement
+		if err := json.Unmarshal(data, &view); err != nil {
+			return fmt.Errorf("template %s: %w", name, err)
+		}
+		violations = view.Validate()
+	} else {
+		var msg SectionBlocks
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return fmt.Errorf("template %s: %w", name, err)
+		}
+		violations = msg.Validate()
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("template %s: %w", name, violations)
+	}
+
+	t.mu.Lock()
+	t.cache[name] = tmpl
+	t.mu.Unlock()
+
+	return nil
+}
+
+// View renders a modal or Home tab
+func (t *Templates) View(name string, data interface{}) (*ViewElement, error) {
+	b, err := t.Execute(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var view ViewElement
+	if err := json.Unmarshal(b, &view); err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	return &view, nil
+}
+
+// Message renders a message
+func (t *Templates) Message(name string, data interface{}) (*SectionBlocks, error) {
+	b, err := t.Execute(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg SectionBlocks
+	if err := json.Unmarshal(b, &msg); err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	return &msg, nil
+}
+
+// Execute renders a template into JSON. Templates that were not loaded before are parsed
+// and cached on first use.
+func (t *Templates) Execute(name string, data interface{}) ([]byte, error) {
+	t.mu.RLock()
+	tmpl, ok := t.cache[name]
+	t.mu.RUnlock()
+
+	if !ok {
+		var err error
+		if tmpl, err = t.parse(name); err != nil {
+			return nil, err
+		}
+		t.mu.Lock()
+		t.cache[name] = tmpl
+		t.mu.Unlock()
+	}
+
+	return t.execute(name, tmpl, data)
+}
+
+func (t *Templates) parse(name string) (*template.Template, error) {
+	src, err := t.fs.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(path.Base(name)).Funcs(defaultTemplateFuncs).Funcs(t.funcs).Parse(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+func (t *Templates) execute(name string, tmpl *template.Template, data interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+
+	if !isYAML(name) {
+		return buf.Bytes(), nil
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	b, err := json.Marshal(yamlToJSON(doc))
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	return b, nil
+}
+
+func isYAML(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// yamlToJSON converts the map[interface{}]interface{} of yaml.v2 into JSON compatible maps
+func yamlToJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, x := range v {
+			m[fmt.Sprint(k)] = yamlToJSON(x)
+		}
+		return m
+	case []interface{}:
+		for i := range v {
+			v[i] = yamlToJSON(v[i])
+		}
+		return v
+	}
+	return v
+}
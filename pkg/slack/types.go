@@ -17,6 +17,15 @@ type (
 		Messages []string `json:"messages,omitempty"`
 	}
 
+	// EphemeralMessage see https://api.slack.com/methods/chat.postEphemeral
+	EphemeralMessage struct {
+		Channel  string  `json:"channel"`
+		User     string  `json:"user"`
+		Text     string  `json:"text,omitempty"`
+		Blocks   []Block `json:"blocks,omitempty"`
+		ThreadTS string  `json:"thread_ts,omitempty"`
+	}
+
 	// WebhookElement not sure?
 	WebhookElement struct {
 		URL              string `json:"url,omitempty"`
@@ -51,4 +60,32 @@ type (
 		Image132     string `json:"image_132,omitempty"`
 		ImageDefault bool   `json:"image_default,omitempty"`
 	}
+
+	// UserInfo see https://api.slack.com/methods/users.info
+	UserInfo struct {
+		OK    bool        `json:"ok"`
+		Error string      `json:"error,omitempty"`
+		User  UserElement `json:"user"`
+	}
+
+	// UserElement see https://api.slack.com/types/user
+	UserElement struct {
+		ID             string `json:"id,omitempty"`
+		TeamID         string `json:"team_id,omitempty"`
+		Name           string `json:"name,omitempty"`
+		RealName       string `json:"real_name,omitempty"`
+		Deleted        bool   `json:"deleted,omitempty"`
+		IsAdmin        bool   `json:"is_admin,omitempty"`
+		IsOwner        bool   `json:"is_owner,omitempty"`
+		IsPrimaryOwner bool   `json:"is_primary_owner,omitempty"`
+		IsRestricted   bool   `json:"is_restricted,omitempty"`
+		IsBot          bool   `json:"is_bot,omitempty"`
+	}
+
+	// UsergroupUsers see https://api.slack.com/methods/usergroups.users.list
+	UsergroupUsers struct {
+		OK    bool     `json:"ok"`
+		Error string   `json:"error,omitempty"`
+		Users []string `json:"users,omitempty"`
+	}
 )
@@ -0,0 +1,562 @@
+package slack
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits as documented in https://api.slack.com/reference/block-kit
+
+const (
+	maxViewBlocks      = 100
+	maxMessageBlocks   = 50
+	maxViewTitle       = 24
+	maxViewButton      = 24
+	maxPrivateMetadata = 3000
+	maxID              = 255
+	maxSectionText     = 3000
+	maxSectionFields   = 10
+	maxFieldText       = 2000
+	maxHeaderText      = 150
+	maxContextElements = 10
+	maxActionsElements = 25
+	maxLabel           = 2000
+	maxURL             = 3000
+	maxAltText         = 2000
+	maxButtonText      = 75
+	maxButtonValue     = 2000
+	maxPlaceholder     = 150
+	maxOptions         = 100
+	maxOptionGroups    = 100
+	maxOptionText      = 75
+	maxOptionValue     = 150
+	maxChoiceOptions   = 10
+	minOverflowOptions = 2
+	maxOverflowOptions = 5
+)
+
+type (
+	// Violation describes a rule a payload breaks, e.g. "blocks[3].text: exceeds 3000 characters"
+	Violation struct {
+		Path    string
+		Message string
+	}
+
+	// Violations is a list of violations. It implements error.
+	Violations []Violation
+
+	// validator collects violations and tracks IDs that must be unique
+	validator struct {
+		violations Violations
+		blockIDs   map[string]string
+		actionIDs  map[string]string
+	}
+)
+
+// validation is enforced by Post and CustomPost if enabled
+var enforceValidation bool
+
+// ValidateBeforeSend enables local validation of views and messages in Post and CustomPost.
+// Invalid payloads are not sent, the Violations are returned as error instead.
+func ValidateBeforeSend(enabled bool) {
+	enforceValidation = enabled
+}
+
+func (v Violation) String() string {
+	return v.Path + ": " + v.Message
+}
+
+func (v Violations) Error() string {
+	s := make([]string, len(v))
+	for i := range v {
+		s[i] = v[i].String()
+	}
+	return "invalid blocks: " + strings.Join(s, "; ")
+}
+
+// Validate checks a modal or Home tab against Slack's documented limits
+func (v ViewElement) Validate() Violations {
+	val := newValidator()
+
+	if len(v.Blocks) > maxViewBlocks {
+		val.add("blocks", "more than %d blocks", maxViewBlocks)
+	}
+	if v.Type == "modal" {
+		val.maxLen("title", v.Title.Text, maxViewTitle)
+		if v.Title.Type != "plain_text" {
+			val.add("title", "must be plain_text")
+		}
+		if v.Submit != nil {
+			val.maxLen("submit", v.Submit.Text, maxViewButton)
+		}
+		if v.Close != nil {
+			val.maxLen("close", v.Close.Text, maxViewButton)
+		}
+		if v.Submit == nil && hasInputBlock(v.Blocks) {
+			val.add("submit", "required if the view contains input blocks")
+		}
+	}
+	val.maxLen("private_metadata", v.PrivateMetadata, maxPrivateMetadata)
+	val.maxLen("callback_id", v.CallbackID, maxID)
+	val.maxLen("external_id", v.ExternalID, maxID)
+
+	val.blocks("blocks", v.Blocks)
+	return val.result()
+}
+
+// Validate checks the view of a modal request
+func (r ModalRequest) Validate() Violations {
+	return r.View.Validate()
+}
+
+// Validate checks a message against Slack's documented limits
+func (m SectionBlocks) Validate() Violations {
+	val := newValidator()
+
+	if len(m.Blocks) > maxMessageBlocks {
+		val.add("blocks", "more than %d blocks", maxMessageBlocks)
+	}
+	if m.ResponseType != "" && m.ResponseType != SlackResponseTypeChannel && m.ResponseType != SlackResponseTypeEphemeral {
+		val.add("response_type", "must be %s or %s", SlackResponseTypeChannel, SlackResponseTypeEphemeral)
+	}
+	val.blocks("blocks", m.Blocks)
+	return val.result()
+}
+
+// Validate checks the block
+func (b SectionBlock) Validate() Violations { return validateBlock(b) }
+
+// Validate checks the block
+func (b DividerBlock) Validate() Violations { return validateBlock(b) }
+
+// Validate checks the block
+func (b HeaderBlock) Validate() Violations { return validateBlock(b) }
+
+// Validate checks the block
+func (b ContextBlock) Validate() Violations { return validateBlock(b) }
+
+// Validate checks the block
+func (b ImageBlock) Validate() Violations { return validateBlock(b) }
+
+// Validate checks the block
+func (b ActionsBlock) Validate() Violations { return validateBlock(b) }
+
+// Validate checks the block
+func (b FileBlock) Validate() Violations { return validateBlock(b) }
+
+// Validate checks the block
+func (b VideoBlock) Validate() Violations { return validateBlock(b) }
+
+// Validate checks the block
+func (b InputBlock) Validate() Violations { return validateBlock(b) }
+
+// Validate checks the block
+func (b RichTextBlock) Validate() Violations { return validateBlock(b) }
+
+func validateBlock(b Block) Violations {
+	val := newValidator()
+	val.block(b.BlockType(), b)
+	return val.result()
+}
+
+// validatePayload is used by Post and CustomPost
+func validatePayload(request interface{}) error {
+	if !enforceValidation || isNil(request) {
+		return nil
+	}
+	if v, ok := request.(interface{ Validate() Violations }); ok {
+		if violations := v.Validate(); violations != nil {
+			return violations
+		}
+	}
+	return nil
+}
+
+func newValidator() *validator {
+	return &validator{
+		blockIDs:  make(map[string]string),
+		actionIDs: make(map[string]string),
+	}
+}
+
+func (val *validator) result() Violations {
+	if len(val.violations) == 0 {
+		return nil
+	}
+	return val.violations
+}
+
+func (val *validator) add(path, format string, args ...interface{}) {
+	val.violations = append(val.violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (val *validator) maxLen(path, s string, max int) {
+	if utf8.RuneCountInString(s) > max {
+		val.add(path, "exceeds %d characters", max)
+	}
+}
+
+func (val *validator) required(path, s string) {
+	if s == "" {
+		val.add(path, "required")
+	}
+}
+
+func (val *validator) plainText(path string, t *TextObject, max int) {
+	if t == nil {
+		return
+	}
+	if t.Type != "plain_text" {
+		val.add(path, "must be plain_text")
+	}
+	val.maxLen(path+".text", t.Text, max)
+}
+
+func (val *validator) text(path string, t *TextObject, max int) {
+	if t == nil {
+		return
+	}
+	if t.Type != "plain_text" && t.Type != "mrkdwn" {
+		val.add(path+".type", "must be plain_text or mrkdwn")
+	}
+	val.maxLen(path+".text", t.Text, max)
+}
+
+func (val *validator) blockID(path, id string) {
+	if id == "" {
+		return
+	}
+	val.maxLen(path+".block_id", id, maxID)
+	if other, ok := val.blockIDs[id]; ok {
+		val.add(path+".block_id", "duplicate block_id '%s', also used by %s", id, other)
+		return
+	}
+	val.blockIDs[id] = path
+}
+
+func (val *validator) actionID(path, id string) {
+	if id == "" {
+		return
+	}
+	val.maxLen(path+".action_id", id, maxID)
+	if other, ok := val.actionIDs[id]; ok {
+		val.add(path+".action_id", "duplicate action_id '%s', also used by %s", id, other)
+		return
+	}
+	val.actionIDs[id] = path
+}
+
+func (val *validator) blocks(path string, blocks []Block) {
+	for i, b := range blocks {
+		val.block(fmt.Sprintf("%s[%d]", path, i), b)
+	}
+}
+
+func (val *validator) block(path string, b Block) {
+	if isNil(b) {
+		val.add(path, "nil block")
+		return
+	}
+
+	// action_id must be unique within its block only
+	val.actionIDs = make(map[string]string)
+
+	switch b := pointerTo(b).(type) {
+	case *SectionBlock:
+		val.section(path, b)
+	case *DividerBlock:
+		val.blockID(path, b.BlockID)
+	case *HeaderBlock:
+		val.header(path, b)
+	case *ContextBlock:
+		val.context(path, b)
+	case *ImageBlock:
+		val.image(path, b)
+	case *ActionsBlock:
+		val.actions(path, b)
+	case *FileBlock:
+		val.blockID(path, b.BlockID)
+		val.required(path+".external_id", b.ExternalID)
+	case *VideoBlock:
+		val.video(path, b)
+	case *InputBlock:
+		val.input(path, b)
+	case *RichTextBlock:
+		val.blockID(path, b.BlockID)
+	}
+}
+
+func (val *validator) section(path string, b *SectionBlock) {
+	val.blockID(path, b.BlockID)
+
+	if b.Text.Text == "" && len(b.Fields) == 0 {
+		val.add(path, "text or fields required")
+	}
+	if b.Text.Text != "" {
+		val.text(path+".text", &b.Text, maxSectionText)
+	}
+	if len(b.Fields) > maxSectionFields {
+		val.add(path+".fields", "more than %d fields", maxSectionFields)
+	}
+	for i := range b.Fields {
+		val.text(fmt.Sprintf("%s.fields[%d]", path, i), &b.Fields[i], maxFieldText)
+	}
+	if b.Accessory != nil {
+		val.element(path+".accessory", b.Accessory)
+	}
+}
+
+func (val *validator) header(path string, b *HeaderBlock) {
+	val.blockID(path, b.BlockID)
+	val.plainText(path+".text", &b.Text, maxHeaderText)
+}
+
+func (val *validator) context(path string, b *ContextBlock) {
+	val.blockID(path, b.BlockID)
+
+	if len(b.Elements) == 0 {
+		val.add(path+".elements", "required")
+	}
+	if len(b.Elements) > maxContextElements {
+		val.add(path+".elements", "more than %d elements", maxContextElements)
+	}
+	for i, e := range b.Elements {
+		p := fmt.Sprintf("%s.elements[%d]", path, i)
+		switch e := pointerTo(e).(type) {
+		case nil:
+			val.add(p, "nil element")
+		case *TextObject:
+			val.text(p, e, maxSectionText)
+		case *ImageElement:
+			val.imageElement(p, e)
+		default:
+			val.add(p, "'%s' is not allowed in a context block", e.(ContextElement).ContextElementType())
+		}
+	}
+}
+
+func (val *validator) image(path string, b *ImageBlock) {
+	val.blockID(path, b.BlockID)
+
+	if b.ImageURL == "" && b.SlackFile == nil {
+		val.add(path+".image_url", "required")
+	}
+	val.maxLen(path+".image_url", b.ImageURL, maxURL)
+	val.required(path+".alt_text", b.AltText)
+	val.maxLen(path+".alt_text", b.AltText, maxAltText)
+	val.plainText(path+".title", b.Title, maxLabel)
+}
+
+func (val *validator) actions(path string, b *ActionsBlock) {
+	val.blockID(path, b.BlockID)
+
+	if len(b.Elements) == 0 {
+		val.add(path+".elements", "required")
+	}
+	if len(b.Elements) > maxActionsElements {
+		val.add(path+".elements", "more than %d elements", maxActionsElements)
+	}
+	for i, e := range b.Elements {
+		val.element(fmt.Sprintf("%s.elements[%d]", path, i), e)
+	}
+}
+
+func (val *validator) video(path string, b *VideoBlock) {
+	val.blockID(path, b.BlockID)
+
+	val.required(path+".alt_text", b.AltText)
+	val.required(path+".video_url", b.VideoURL)
+	val.required(path+".thumbnail_url", b.ThumbnailURL)
+	val.plainText(path+".title", &b.Title, 200)
+	val.plainText(path+".description", b.Description, 200)
+}
+
+func (val *validator) input(path string, b *InputBlock) {
+	val.blockID(path, b.BlockID)
+
+	val.plainText(path+".label", &b.Label, maxLabel)
+	val.plainText(path+".hint", b.Hint, maxLabel)
+	if b.Element == nil {
+		val.add(path+".element", "required")
+		return
+	}
+	val.element(path+".element", b.Element)
+}
+
+func (val *validator) element(path string, e BlockElement) {
+	if isNil(e) {
+		val.add(path, "nil element")
+		return
+	}
+
+	elem := e
+	switch e := pointerTo(e).(type) {
+	case *ImageElement:
+		val.imageElement(path, e)
+	case *Button:
+		val.button(path, e)
+	case *OverflowMenu:
+		val.actionID(path, e.ActionID)
+		if len(e.Options) < minOverflowOptions || len(e.Options) > maxOverflowOptions {
+			val.add(path+".options", "between %d and %d options required", minOverflowOptions, maxOverflowOptions)
+		}
+		val.options(path+".options", e.Options, true)
+	case *Checkboxes:
+		val.actionID(path, e.ActionID)
+		val.choices(path+".options", e.Options)
+	case *Radiobuttons:
+		val.actionID(path, e.ActionID)
+		val.choices(path+".options", e.Options)
+	case *StaticSelect:
+		val.actionID(path, e.ActionID)
+		val.selectOptions(path, e.Options, e.OptionGroups)
+		val.plainText(path+".placeholder", e.Placeholder, maxPlaceholder)
+	case *MultiStaticSelect:
+		val.actionID(path, e.ActionID)
+		val.selectOptions(path, e.Options, e.OptionGroups)
+		val.plainText(path+".placeholder", e.Placeholder, maxPlaceholder)
+	default:
+		val.genericElement(path, elem)
+	}
+}
+
+// genericElement checks action_id and placeholder of elements without special rules
+func (val *validator) genericElement(path string, e BlockElement) {
+	var actionID string
+	var placeholder *TextObject
+
+	switch e := pointerTo(e).(type) {
+	case *ExternalSelect:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *MultiExternalSelect:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *UsersSelect:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *MultiUsersSelect:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *ConversationsSelect:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *MultiConversationsSelect:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *ChannelsSelect:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *MultiChannelsSelect:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *DatePicker:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *TimePicker:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *DateTimePicker:
+		actionID = e.ActionID
+	case *PlainTextInput:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *EmailInput:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *URLInput:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *NumberInput:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	case *RichTextInput:
+		actionID, placeholder = e.ActionID, e.Placeholder
+	default:
+		return
+	}
+
+	val.actionID(path, actionID)
+	val.plainText(path+".placeholder", placeholder, maxPlaceholder)
+}
+
+func (val *validator) imageElement(path string, e *ImageElement) {
+	if e.ImageURL == "" && e.SlackFile == nil {
+		val.add(path+".image_url", "required")
+	}
+	val.maxLen(path+".image_url", e.ImageURL, maxURL)
+	val.required(path+".alt_text", e.AltText)
+}
+
+func (val *validator) button(path string, e *Button) {
+	val.actionID(path, e.ActionID)
+	val.plainText(path+".text", &e.Text, maxButtonText)
+	val.maxLen(path+".value", e.Value, maxButtonValue)
+	val.maxLen(path+".url", e.URL, maxURL)
+	if e.Style != "" && e.Style != "primary" && e.Style != "danger" {
+		val.add(path+".style", "must be primary or danger")
+	}
+}
+
+func (val *validator) selectOptions(path string, options []OptionsObject, groups []OptionGroupObject) {
+	if len(options) > 0 && len(groups) > 0 {
+		val.add(path, "options and option_groups are mutually exclusive")
+	}
+	if len(options) > maxOptions {
+		val.add(path+".options", "more than %d options", maxOptions)
+	}
+	val.options(path+".options", options, true)
+
+	if len(groups) > maxOptionGroups {
+		val.add(path+".option_groups", "more than %d option groups", maxOptionGroups)
+	}
+	for i, g := range groups {
+		p := fmt.Sprintf("%s.option_groups[%d]", path, i)
+		val.plainText(p+".label", &g.Label, maxOptionText)
+		if len(g.Options) > maxOptions {
+			val.add(p+".options", "more than %d options", maxOptions)
+		}
+		val.options(p+".options", g.Options, true)
+	}
+}
+
+// choices checks the options of checkboxes and radio buttons, which allow mrkdwn
+func (val *validator) choices(path string, options []OptionsObject) {
+	if len(options) > maxChoiceOptions {
+		val.add(path, "more than %d options", maxChoiceOptions)
+	}
+	val.options(path, options, false)
+}
+
+func (val *validator) options(path string, options []OptionsObject, plainTextOnly bool) {
+	for i := range options {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		if plainTextOnly {
+			val.plainText(p+".text", &options[i].Text, maxOptionText)
+		} else {
+			val.text(p+".text", &options[i].Text, maxOptionText)
+		}
+		val.maxLen(p+".value", options[i].Value, maxOptionValue)
+	}
+}
+
+func hasInputBlock(blocks []Block) bool {
+	for _, b := range blocks {
+		if b != nil && b.BlockType() == "input" {
+			return true
+		}
+	}
+	return false
+}
+
+// pointerTo returns a pointer to a copy of v if v is not a pointer, so that type switches need pointer cases only.
+// Nil pointers are returned as nil.
+func pointerTo(v interface{}) interface{} {
+	if isNil(v) {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		return v
+	}
+	p := reflect.New(rv.Type())
+	p.Elem().Set(rv)
+	return p.Interface()
+}
+
+// isNil returns true for nil and for typed nil pointers like (*SectionBlock)(nil)
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
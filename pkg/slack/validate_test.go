@@ -0,0 +1,113 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+)
+
+func plain(text string) TextObject {
+	return TextObject{Type: "plain_text", Text: text}
+}
+
+func input(blockID, actionID string) *InputBlock {
+	return &InputBlock{BlockID: blockID, Label: plain("Label"), Element: &PlainTextInput{ActionID: actionID}}
+}
+
+func TestValidateView(t *testing.T) {
+	tests := []struct {
+		name string
+		view ViewElement
+		want []string // paths with violations
+	}{
+		{
+			"valid",
+			ViewElement{Type: "modal", Title: DefaultViewElement{Type: "plain_text", Text: "Title"}, Submit: &DefaultViewElement{Type: "plain_text", Text: "OK"},
+				Blocks: []Block{input("a", "value"), input("b", "other")}},
+			nil,
+		},
+		{
+			"action_id reused in different blocks",
+			ViewElement{Type: "modal", Title: DefaultViewElement{Type: "plain_text", Text: "Title"}, Submit: &DefaultViewElement{Type: "plain_text", Text: "OK"},
+				Blocks: []Block{input("a", "value"), input("b", "value")}},
+			nil,
+		},
+		{
+			"duplicate action_id in a block",
+			ViewElement{Type: "modal", Title: DefaultViewElement{Type: "plain_text", Text: "Title"},
+				Blocks: []Block{&ActionsBlock{Elements: []BlockElement{
+					&Button{Text: plain("A"), ActionID: "go"},
+					&Button{Text: plain("B"), ActionID: "go"},
+				}}}},
+			[]string{"blocks[0].elements[1].action_id"},
+		},
+		{
+			"duplicate block_id",
+			ViewElement{Type: "modal", Title: DefaultViewElement{Type: "plain_text", Text: "Title"}, Submit: &DefaultViewElement{Type: "plain_text", Text: "OK"},
+				Blocks: []Block{input("a", "x"), input("a", "y")}},
+			[]string{"blocks[1].block_id"},
+		},
+		{
+			"title too long",
+			ViewElement{Type: "modal", Title: DefaultViewElement{Type: "plain_text", Text: strings.Repeat("x", 25)}},
+			[]string{"title"},
+		},
+		{
+			"input without submit",
+			ViewElement{Type: "modal", Title: DefaultViewElement{Type: "plain_text", Text: "Title"}, Blocks: []Block{input("a", "x")}},
+			[]string{"submit"},
+		},
+		{
+			"typed nil block",
+			ViewElement{Type: "home", Blocks: []Block{(*SectionBlock)(nil)}},
+			[]string{"blocks[0]"},
+		},
+		{
+			"typed nil element",
+			ViewElement{Type: "home", Blocks: []Block{&ActionsBlock{Elements: []BlockElement{(*Button)(nil)}}}},
+			[]string{"blocks[0].elements[0]"},
+		},
+		{
+			"typed nil accessory",
+			ViewElement{Type: "home", Blocks: []Block{&SectionBlock{Text: plain("x"), Accessory: (*Button)(nil)}}},
+			[]string{"blocks[0].accessory"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range tt.view.Validate() {
+				got = append(got, v.Path)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Validate() = %v, want violations at %v", tt.view.Validate(), tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  SectionBlocks
+		want []string
+	}{
+		{"valid", SectionBlocks{ResponseType: SlackResponseTypeChannel, Blocks: []Block{&SectionBlock{Text: plain("hi")}}}, nil},
+		{"value block", SectionBlocks{Blocks: []Block{SectionBlock{Text: plain("hi")}}}, nil},
+		{"invalid response type", SectionBlocks{ResponseType: "public"}, []string{"response_type"}},
+		{"empty section", SectionBlocks{Blocks: []Block{&SectionBlock{}}}, []string{"blocks[0]"}},
+		{"section text too long", SectionBlocks{Blocks: []Block{&SectionBlock{Text: plain(strings.Repeat("x", 3001))}}}, []string{"blocks[0].text.text"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range tt.msg.Validate() {
+				got = append(got, v.Path)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Validate() = %v, want violations at %v", tt.msg.Validate(), tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package slack
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"golang.org/x/net/context"
@@ -35,9 +36,14 @@ func Get(ctx context.Context, token, apiMethod, query string, response interface
 }
 
 // Post is used to invoke a Slack Web API method by posting a JSON payload.
+// Views and messages are validated first if ValidateBeforeSend is enabled.
 func Post(ctx context.Context, token, apiMethod string, request interface{}) (*StandardResponse, error) {
 	url := SlackEndpoint + apiMethod
 
+	if err := validatePayload(request); err != nil {
+		return nil, err
+	}
+
 	m, err := json.Marshal(&request)
 	if err != nil {
 		return nil, err
@@ -70,6 +76,10 @@ func Post(ctx context.Context, token, apiMethod string, request interface{}) (*S
 func CustomPost(ctx context.Context, token, apiMethod string, request, response interface{}) error {
 	url := SlackEndpoint + apiMethod
 
+	if err := validatePayload(request); err != nil {
+		return err
+	}
+
 	m, err := json.Marshal(&request)
 	if err != nil {
 		return err
@@ -96,3 +106,26 @@ func CustomPost(ctx context.Context, token, apiMethod string, request, response
 
 	return err
 }
+
+// Respond sends a message to the response_url of a slash command or interaction
+func Respond(ctx context.Context, responseURL string, msg *SectionBlocks) error {
+	if err := validatePayload(msg); err != nil {
+		return err
+	}
+
+	m, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(responseURL, "application/json; charset=utf-8", bytes.NewBuffer(m))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Response to '%s' failed with status %d", responseURL, resp.StatusCode)
+	}
+	return nil
+}